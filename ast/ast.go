@@ -2,7 +2,10 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
 	"go-intepreter/token"
+	"math/big"
+	"strings"
 )
 
 // AST adalah singkatan dari "Abstract Syntax Tree" atau "Pohon Sintaksis Abstrak" dalam bahasa Indonesia.
@@ -13,6 +16,11 @@ type Node interface {
 	// membuat tiga bidang: satu untuk pengenal, satu untuk ekspresi yang menghasilkan nilai dalam pernyataan let dan satu untuk token.
 	TokenLiteral() string
 	String() string // mencetak node AST untuk debugging dan membandingkannya dengan node AST lainnya
+
+	// Pos dan End mengembalikan posisi awal node dan posisi tepat setelah akhir node di dalam kode sumber.
+	// Keduanya digunakan untuk menunjuk lokasi pada pesan kesalahan.
+	Pos() token.Position
+	End() token.Position
 }
 
 type Statement interface {
@@ -41,6 +49,20 @@ func (p *Program) TokenLiteral() string {
 	}
 }
 
+func (p *Program) Pos() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return token.Position{}
+}
+
+func (p *Program) End() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[len(p.Statements)-1].End()
+	}
+	return token.Position{}
+}
+
 // LetStatement memiliki bidang-bidang yang kita butuhkan: Nama untuk menyimpan pengenal pengikatan dan Nilai untuk yang menghasilkan nilai.
 // Dua metode statementNode dan TokenLiteral memenuhi antarmuka Statement dan Node masing-masing.
 type LetStatement struct {
@@ -57,6 +79,20 @@ func (ls *LetStatement) TokenLiteral() string {
 	return ls.Token.Literal
 }
 
+func (ls *LetStatement) Pos() token.Position {
+	return ls.Token.Pos
+}
+
+func (ls *LetStatement) End() token.Position {
+	if ls.Value != nil {
+		return ls.Value.End()
+	}
+	if ls.Name != nil {
+		return ls.Name.End()
+	}
+	return ls.Token.End
+}
+
 // Untuk menjaga jumlah tipe simpul yang berbeda tetap kecil,
 // kita akan menggunakan Identifier di sini untuk merepresentasikan nama dalam pengikatan variabel dan kemudian menggunakannya kembali,
 // untuk merepresentasikan sebuah pengenal sebagai bagian dari atau sebagai lengkap dari sebuah ekspresi.
@@ -75,6 +111,14 @@ func (i *Identifier) TokenLiteral() string {
 	return i.Token.Literal
 }
 
+func (i *Identifier) Pos() token.Position {
+	return i.Token.Pos
+}
+
+func (i *Identifier) End() token.Position {
+	return i.Token.End
+}
+
 // Dengan Program, LetStatement dan Identifier mendefinisikan bagian kode sumber COKLang ini;
 // let x = 5;
 
@@ -95,6 +139,17 @@ func (rs *ReturnStatement) TokenLiteral() string {
 	return rs.Token.Literal
 }
 
+func (rs *ReturnStatement) Pos() token.Position {
+	return rs.Token.Pos
+}
+
+func (rs *ReturnStatement) End() token.Position {
+	if rs.ReturnValue != nil {
+		return rs.ReturnValue.End()
+	}
+	return rs.Token.End
+}
+
 // let x = 5;
 // x + 10;
 // Baris pertama adalah pernyataan let, baris kedua adalah pernyataan ekspresi
@@ -109,6 +164,17 @@ func (rs *ExpressionStatement) TokenLiteral() string {
 	return rs.Token.Literal
 }
 
+func (rs *ExpressionStatement) Pos() token.Position {
+	return rs.Token.Pos
+}
+
+func (rs *ExpressionStatement) End() token.Position {
+	if rs.Expression != nil {
+		return rs.Expression.End()
+	}
+	return rs.Token.End
+}
+
 // Metode ini hanya membuat sebuah buffer dan menulis nilai kembalian dari setiap
 // pernyataan metode String() ke dalamnya. Dan kemudian mengembalikan buffer tersebut sebagai sebuah string. Ini mendelegasikan sebagian besar
 // dari pekerjaannya ke Pernyataan *ast.Program.
@@ -165,9 +231,12 @@ func (i *Identifier) String() string {
 // Nilai adalah sebuah int64 dan bukan sebuah string.
 // Ini adalah bidang yang akan berisi nilai aktual yang diwakili oleh literal bilangan bulat yang diwakili dalam kode sumber.
 // Ketika kita membuat *ast.IntegerLiteral, kita harus mengonversi string dalam *ast.IntegerLiteral.Token.Literal (yang merupakan sesuatu seperti "5") menjadi int64.
+//
+// Literal yang terlalu besar untuk int64 (misalnya 99999999999999999999) disimpan di Big, dan Value bernilai 0.
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big   *big.Int
 }
 
 func (il *IntegerLiteral) expressionNode() {}
@@ -176,10 +245,43 @@ func (il *IntegerLiteral) TokenLiteral() string {
 	return il.Token.Literal
 }
 
+func (il *IntegerLiteral) Pos() token.Position {
+	return il.Token.Pos
+}
+
+func (il *IntegerLiteral) End() token.Position {
+	return il.Token.End
+}
+
 func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+// FloatLiteral adalah literal bilangan pecahan seperti 3.14 atau 1e-9.
+// Sama seperti IntegerLiteral, String mengembalikan literal apa adanya dari kode sumber.
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) Pos() token.Position {
+	return fl.Token.Pos
+}
+
+func (fl *FloatLiteral) End() token.Position {
+	return fl.Token.End
+}
+
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
 type PrefixExpression struct {
 	Token    token.Token // The prefix token, e.g. !
 	Operator string
@@ -194,6 +296,17 @@ func (pe *PrefixExpression) TokenLiteral() string {
 	return pe.Token.Literal
 }
 
+func (pe *PrefixExpression) Pos() token.Position {
+	return pe.Token.Pos
+}
+
+func (pe *PrefixExpression) End() token.Position {
+	if pe.Right != nil {
+		return pe.Right.End()
+	}
+	return pe.Token.End
+}
+
 // Node *ast.PrefixExpression memiliki dua bidang yang perlu diperhatikan:
 // Operator dan Right. Operator adalah sebuah string yang akan berisi "-" atau "!". Bidang Right
 // berisi ekspresi di sebelah kanan operator.
@@ -222,6 +335,20 @@ func (oe *InfixExpression) expressionNode() {}
 func (oe *InfixExpression) TokenLiteral() string {
 	return oe.Token.Literal
 }
+
+func (oe *InfixExpression) Pos() token.Position {
+	if oe.Left != nil {
+		return oe.Left.Pos()
+	}
+	return oe.Token.Pos
+}
+
+func (oe *InfixExpression) End() token.Position {
+	if oe.Right != nil {
+		return oe.Right.End()
+	}
+	return oe.Token.End
+}
 func (oe *InfixExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -233,3 +360,454 @@ func (oe *InfixExpression) String() string {
 	return out.String()
 
 }
+
+// Boolean merepresentasikan literal true dan false.
+// Value berisi nilai bool Go yang sesuai dengan token.TRUE atau token.FALSE.
+type Boolean struct {
+	Token token.Token
+	Value bool
+}
+
+func (b *Boolean) expressionNode() {}
+
+func (b *Boolean) TokenLiteral() string {
+	return b.Token.Literal
+}
+
+func (b *Boolean) Pos() token.Position {
+	return b.Token.Pos
+}
+
+func (b *Boolean) End() token.Position {
+	return b.Token.End
+}
+
+func (b *Boolean) String() string {
+	return b.Token.Literal
+}
+
+// IfExpression merepresentasikan if (<kondisi>) <konsekuensi> else <alternatif>.
+// Di CokLang if adalah ekspresi, sehingga ia menghasilkan nilai:
+// let x = if (a > b) { a } else { b };
+// Alternative boleh nil jika tidak ada cabang else. Untuk rantai "else if",
+// Alternative berisi BlockStatement dengan satu pernyataan yang membungkus IfExpression berikutnya.
+type IfExpression struct {
+	Token       token.Token // token 'if'
+	Condition   Expression
+	Consequence *BlockStatement
+	Alternative *BlockStatement
+}
+
+func (ie *IfExpression) expressionNode() {}
+
+func (ie *IfExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+func (ie *IfExpression) Pos() token.Position {
+	return ie.Token.Pos
+}
+
+func (ie *IfExpression) End() token.Position {
+	if ie.Alternative != nil {
+		return ie.Alternative.End()
+	}
+	if ie.Consequence != nil {
+		return ie.Consequence.End()
+	}
+	return ie.Token.End
+}
+
+// String menghasilkan kode sumber yang dapat diurai kembali: if (<kondisi>) { ... } else { ... }.
+// Kondisi infiks dan prefiks sudah diapit tanda kurung oleh String()-nya sendiri, jadi tidak
+// dibungkus lagi. Rantai "else if" yang dibuat parser dicetak kembali sebagai else if.
+func (ie *IfExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("if ")
+	switch ie.Condition.(type) {
+	case *InfixExpression, *PrefixExpression:
+		out.WriteString(ie.Condition.String())
+	default:
+		out.WriteString("(" + ie.Condition.String() + ")")
+	}
+	out.WriteString(" ")
+	out.WriteString(ie.Consequence.String())
+
+	if ie.Alternative != nil {
+		out.WriteString(" else ")
+		if ie.Alternative.Token.Type == token.IF && len(ie.Alternative.Statements) == 1 {
+			out.WriteString(ie.Alternative.Statements[0].String())
+		} else {
+			out.WriteString(ie.Alternative.String())
+		}
+	}
+	return out.String()
+}
+
+// BlockStatement adalah serangkaian pernyataan di antara { dan }.
+type BlockStatement struct {
+	Token      token.Token // token '{'
+	Statements []Statement
+	Rbrace     token.Token // token '}', kosong untuk blok "else if" yang dibuat parser
+}
+
+func (bs *BlockStatement) statementNode() {}
+
+func (bs *BlockStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+func (bs *BlockStatement) Pos() token.Position {
+	return bs.Token.Pos
+}
+
+func (bs *BlockStatement) End() token.Position {
+	if bs.Rbrace.End.IsValid() {
+		return bs.Rbrace.End
+	}
+	if len(bs.Statements) > 0 {
+		return bs.Statements[len(bs.Statements)-1].End()
+	}
+	return bs.Token.End
+}
+
+// String mencetak blok beserta kurung kurawalnya, misalnya { let x = 1; x }. Pernyataan ekspresi
+// yang diikuti pernyataan lain diberi ; agar { a; (b) } tidak terbaca kembali sebagai a(b).
+func (bs *BlockStatement) String() string {
+	if len(bs.Statements) == 0 {
+		return "{ }"
+	}
+
+	var out bytes.Buffer
+	out.WriteString("{ ")
+	for i, s := range bs.Statements {
+		if i > 0 {
+			if _, ok := bs.Statements[i-1].(*ExpressionStatement); ok {
+				out.WriteString(";")
+			}
+			out.WriteString(" ")
+		}
+		out.WriteString(s.String())
+	}
+	out.WriteString(" }")
+	return out.String()
+}
+
+// FunctionLiteral merepresentasikan fn(<parameter>) <blok>.
+// Parameter hanyalah daftar identifier dan Body adalah BlockStatement.
+// Fungsi literal adalah ekspresi, sehingga bisa digunakan di mana saja ekspresi valid:
+// let add = fn(a, b) { a + b; };
+// fn(x) { x * 2 }(5);
+type FunctionLiteral struct {
+	Token      token.Token // token 'fn'
+	Parameters []*Identifier
+	Body       *BlockStatement
+}
+
+func (fl *FunctionLiteral) expressionNode() {}
+
+func (fl *FunctionLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FunctionLiteral) Pos() token.Position {
+	return fl.Token.Pos
+}
+
+func (fl *FunctionLiteral) End() token.Position {
+	if fl.Body != nil {
+		return fl.Body.End()
+	}
+	return fl.Token.End
+}
+
+func (fl *FunctionLiteral) String() string {
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range fl.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString(fl.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") ")
+	out.WriteString(fl.Body.String())
+
+	return out.String()
+}
+
+// CallExpression merepresentasikan <ekspresi>(<argumen>).
+// Function bisa berupa Identifier (add(1, 2)) atau FunctionLiteral (fn(x) { x }(1)).
+type CallExpression struct {
+	Token     token.Token // token '('
+	Function  Expression
+	Arguments []Expression
+	Rparen    token.Token // token ')'
+}
+
+func (ce *CallExpression) expressionNode() {}
+
+func (ce *CallExpression) TokenLiteral() string {
+	return ce.Token.Literal
+}
+
+func (ce *CallExpression) Pos() token.Position {
+	if ce.Function != nil {
+		return ce.Function.Pos()
+	}
+	return ce.Token.Pos
+}
+
+func (ce *CallExpression) End() token.Position {
+	if ce.Rparen.End.IsValid() {
+		return ce.Rparen.End
+	}
+	return ce.Token.End
+}
+
+func (ce *CallExpression) String() string {
+	var out bytes.Buffer
+
+	args := []string{}
+	for _, a := range ce.Arguments {
+		args = append(args, a.String())
+	}
+
+	out.WriteString(ce.Function.String())
+	out.WriteString("(")
+	out.WriteString(strings.Join(args, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+
+// StringLiteral merepresentasikan "<karakter>". Value berisi isi string setelah escape
+// sequence diterjemahkan, sedangkan String() mengembalikan bentuk yang dikutip kembali.
+type StringLiteral struct {
+	Token token.Token
+	Value string
+}
+
+func (sl *StringLiteral) expressionNode() {}
+
+func (sl *StringLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+
+func (sl *StringLiteral) Pos() token.Position {
+	return sl.Token.Pos
+}
+
+func (sl *StringLiteral) End() token.Position {
+	return sl.Token.End
+}
+
+func (sl *StringLiteral) String() string {
+	return quote(sl.Value)
+}
+
+// quote mengembalikan s di antara tanda kutip ganda dengan escape sequence CokLang,
+// sehingga hasilnya dapat diurai kembali oleh lexer.
+func quote(s string) string {
+	var out bytes.Buffer
+	out.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\r':
+			out.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&out, "\\u{%x}", r)
+			} else {
+				out.WriteRune(r)
+			}
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
+// ArrayLiteral merepresentasikan [<ekspresi>, <ekspresi>, ...].
+type ArrayLiteral struct {
+	Token    token.Token // token '['
+	Elements []Expression
+	Rbracket token.Token // token ']'
+}
+
+func (al *ArrayLiteral) expressionNode() {}
+
+func (al *ArrayLiteral) TokenLiteral() string {
+	return al.Token.Literal
+}
+
+func (al *ArrayLiteral) Pos() token.Position {
+	return al.Token.Pos
+}
+
+func (al *ArrayLiteral) End() token.Position {
+	if al.Rbracket.End.IsValid() {
+		return al.Rbracket.End
+	}
+	return al.Token.End
+}
+
+func (al *ArrayLiteral) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, el := range al.Elements {
+		elements = append(elements, el.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// IndexExpression merepresentasikan <ekspresi>[<ekspresi>].
+// Left adalah objek yang diakses dan Index adalah ekspresi apa pun yang menghasilkan indeks.
+type IndexExpression struct {
+	Token    token.Token // token '['
+	Left     Expression
+	Index    Expression
+	Rbracket token.Token // token ']'
+}
+
+func (ie *IndexExpression) expressionNode() {}
+
+func (ie *IndexExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+func (ie *IndexExpression) Pos() token.Position {
+	if ie.Left != nil {
+		return ie.Left.Pos()
+	}
+	return ie.Token.Pos
+}
+
+func (ie *IndexExpression) End() token.Position {
+	if ie.Rbracket.End.IsValid() {
+		return ie.Rbracket.End
+	}
+	return ie.Token.End
+}
+
+func (ie *IndexExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ie.Left.String())
+	out.WriteString("[")
+	out.WriteString(ie.Index.String())
+	out.WriteString("])")
+
+	return out.String()
+}
+
+// HashLiteral merepresentasikan {<ekspresi> : <ekspresi>, ...}.
+// Pairs disimpan sebagai slice agar urutannya sama dengan kode sumber, sehingga String() deterministik.
+type HashLiteral struct {
+	Token  token.Token // token '{'
+	Pairs  []HashPair
+	Rbrace token.Token // token '}'
+}
+
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+func (hl *HashLiteral) expressionNode() {}
+
+func (hl *HashLiteral) TokenLiteral() string {
+	return hl.Token.Literal
+}
+
+func (hl *HashLiteral) Pos() token.Position {
+	return hl.Token.Pos
+}
+
+func (hl *HashLiteral) End() token.Position {
+	if hl.Rbrace.End.IsValid() {
+		return hl.Rbrace.End
+	}
+	return hl.Token.End
+}
+
+func (hl *HashLiteral) String() string {
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.Key.String()+": "+pair.Value.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// BadExpression dan BadStatement adalah placeholder untuk bagian kode sumber yang tidak dapat diurai.
+// Parser menyisipkannya setelah melaporkan kesalahan, sehingga AST tetap utuh dan bagian program
+// lainnya tetap dapat digunakan (misalnya oleh formatter atau linter).
+// Token adalah token pertama yang tidak dapat diurai dan To adalah posisi setelah token terakhir yang dilewati.
+type BadExpression struct {
+	Token token.Token
+	To    token.Position
+}
+
+func (be *BadExpression) expressionNode() {}
+
+func (be *BadExpression) TokenLiteral() string {
+	return be.Token.Literal
+}
+
+func (be *BadExpression) Pos() token.Position {
+	return be.Token.Pos
+}
+
+func (be *BadExpression) End() token.Position {
+	return be.To
+}
+
+func (be *BadExpression) String() string {
+	return "<bad expression>"
+}
+
+type BadStatement struct {
+	Token token.Token
+	To    token.Position
+}
+
+func (bs *BadStatement) statementNode() {}
+
+func (bs *BadStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+func (bs *BadStatement) Pos() token.Position {
+	return bs.Token.Pos
+}
+
+func (bs *BadStatement) End() token.Position {
+	return bs.To
+}
+
+func (bs *BadStatement) String() string {
+	return "<bad statement>"
+}
@@ -0,0 +1,237 @@
+// Package cok menyediakan API untuk menyematkan (embed) bahasa CokLang di dalam program Go,
+// misalnya sebagai bahasa aturan atau konfigurasi.
+//
+//	interp := cok.NewInterpreter(cok.Options{})
+//	interp.Set("limit", 10)
+//	result, err := interp.Eval(`if (limit > 5) { "high" } else { "low" }`)
+//
+// Setiap Interpreter memiliki environment sendiri, sehingga binding dari satu Eval tetap ada di
+// Eval berikutnya. Interpreter tidak aman dipakai bersamaan dari beberapa goroutine.
+//
+// Untuk menjalankan kode yang tidak tepercaya, batasi eksekusinya dengan Options.MaxSteps,
+// Options.MaxDepth, Options.Timeout atau context pada EvalContext. Batas yang terlampaui
+// dikembalikan sebagai *LimitExceeded.
+package cok
+
+import (
+	"context"
+	"fmt"
+	"go-intepreter/ast"
+	"go-intepreter/diagnostic"
+	"go-intepreter/evaluator"
+	"go-intepreter/lexer"
+	"go-intepreter/object"
+	"go-intepreter/parser"
+	"go-intepreter/token"
+	"io"
+	"time"
+)
+
+// Options mengatur Interpreter. Field yang kosong memakai nilai bawaan.
+type Options struct {
+	// Stdout menerima keluaran program, misalnya dari puts. Bawaan: os.Stdout.
+	Stdout io.Writer
+	// Filename dipakai pada posisi di pesan kesalahan (file:line:column). Bawaan: kosong.
+	Filename string
+
+	// MaxSteps membatasi jumlah langkah evaluasi (node AST) per pemanggilan Eval, Call, atau
+	// GoFunc yang didapat dari Interpreter. Bawaan: tanpa batas.
+	MaxSteps int
+	// MaxDepth membatasi kedalaman pemanggilan fungsi CokLang. Bawaan: object.DEFAULT_MAX_DEPTH.
+	MaxDepth int
+	// Timeout membatasi waktu setiap pemanggilan Eval, Call, atau GoFunc yang didapat dari
+	// Interpreter. Bawaan: tanpa batas.
+	Timeout time.Duration
+}
+
+// Interpreter menjalankan kode CokLang dengan environment yang tetap ada di antara pemanggilan.
+type Interpreter struct {
+	env  *object.Environment
+	opts Options
+	// running bernilai true selama Eval, Call atau GoFunc dari Interpreter ini sedang berjalan.
+	running bool
+}
+
+func NewInterpreter(opts Options) *Interpreter {
+	env := object.NewEnvironment()
+	if opts.Stdout != nil {
+		env.SetOutput(opts.Stdout)
+	}
+	return &Interpreter{env: env, opts: opts}
+}
+
+// Eval mengurai dan mengevaluasi src, lalu mengembalikan nilai pernyataan terakhir sebagai
+// nilai Go (lihat ToGo). Pernyataan let tidak menghasilkan nilai, jadi hasilnya nil.
+// Kesalahan sintaks dikembalikan sebagai *SyntaxError, kesalahan runtime sebagai *RuntimeError,
+// dan batas eksekusi yang terlampaui sebagai *LimitExceeded.
+func (i *Interpreter) Eval(src string) (any, error) {
+	return i.EvalContext(context.Background(), src)
+}
+
+// EvalContext sama seperti Eval, tetapi evaluasi dihentikan dengan *LimitExceeded jika ctx
+// dibatalkan atau tenggat waktunya lewat. ctx diperiksa setiap kali sebuah fungsi dipanggil.
+func (i *Interpreter) EvalContext(ctx context.Context, src string) (any, error) {
+	var opts []lexer.Option
+	if i.opts.Filename != "" {
+		opts = append(opts, lexer.WithFilename(i.opts.Filename))
+	}
+
+	p := parser.New(lexer.New(src, opts...))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		return nil, &SyntaxError{Src: src, Diagnostics: p.Errors()}
+	}
+
+	defer i.limit(ctx)()
+	return i.result(evaluator.Eval(program, i.env))
+}
+
+// Set mengikat name ke value di environment global setelah mengubahnya dengan ToObject.
+func (i *Interpreter) Set(name string, value any) error {
+	obj, err := converter{i}.toObject(value)
+	if err != nil {
+		return fmt.Errorf("cok: cannot set %s: %w", name, err)
+	}
+	if builtin, ok := obj.(*object.Builtin); ok {
+		builtin.Name = name
+	}
+	i.env.Set(name, obj)
+	return nil
+}
+
+// Get mengembalikan nilai Go dari name, atau false jika name belum diikat.
+// Fungsi bawaan seperti len tidak termasuk.
+func (i *Interpreter) Get(name string) (any, bool) {
+	obj, ok := i.env.Get(name)
+	if !ok {
+		return nil, false
+	}
+	return converter{i}.toGo(obj), true
+}
+
+// Call memanggil fungsi CokLang bernama fnName (fungsi yang diikat dengan let, atau builtin)
+// dengan argumen Go yang diubah dengan ToObject, dan mengembalikan hasilnya sebagai nilai Go.
+func (i *Interpreter) Call(fnName string, args ...any) (any, error) {
+	return i.CallContext(context.Background(), fnName, args...)
+}
+
+// CallContext sama seperti Call, dengan pembatalan melalui ctx seperti pada EvalContext.
+func (i *Interpreter) CallContext(ctx context.Context, fnName string, args ...any) (any, error) {
+	defer i.limit(ctx)()
+
+	fn := evaluator.Eval(&ast.Identifier{Token: token.Token{Type: token.IDENT, Literal: fnName}, Value: fnName}, i.env)
+	if errObj, ok := fn.(*object.Error); ok {
+		return nil, newRuntimeError(errObj, i.env)
+	}
+
+	objs := make([]object.Object, len(args))
+	for n, arg := range args {
+		obj, err := converter{i}.toObject(arg)
+		if err != nil {
+			return nil, fmt.Errorf("cok: argument %d to %s: %w", n+1, fnName, err)
+		}
+		objs[n] = obj
+	}
+
+	return i.result(evaluator.CallFunction(fn, objs, i.env))
+}
+
+// limit memasang batas dari Options dan ctx pada environment, dengan hitungan langkah dan
+// kedalaman yang baru. Fungsi yang dikembalikan melepas batas tersebut. Pemanggilan yang terjadi
+// di dalam pemanggilan lain, misalnya GoFunc atau Eval yang dipanggil dari fungsi Go di tengah
+// Eval, tetap memakai batas pemanggil luarnya, sehingga batas tidak dapat dihindari lewat kode Go.
+func (i *Interpreter) limit(ctx context.Context) (restore func()) {
+	if i.running {
+		return func() {}
+	}
+	i.running = true
+
+	cancel := func() {}
+	if i.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, i.opts.Timeout)
+	}
+
+	budget := i.env.Budget()
+	saved := *budget
+	*budget = object.Budget{Limits: object.Limits{
+		MaxSteps: i.opts.MaxSteps,
+		MaxDepth: i.opts.MaxDepth,
+		Context:  ctx,
+	}}
+
+	return func() {
+		cancel()
+		*budget = saved
+		i.running = false
+	}
+}
+
+func (i *Interpreter) result(obj object.Object) (any, error) {
+	if errObj, ok := obj.(*object.Error); ok {
+		return nil, newRuntimeError(errObj, i.env)
+	}
+	return converter{i}.toGo(obj), nil
+}
+
+// SyntaxError berisi semua kesalahan sintaks dari satu pemanggilan Eval.
+type SyntaxError struct {
+	Src         string
+	Diagnostics []diagnostic.Diagnostic
+}
+
+// Error mengembalikan kesalahan pertama dalam format "line:column: message".
+func (e *SyntaxError) Error() string {
+	msg := e.Diagnostics[0].String()
+	if n := len(e.Diagnostics) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return msg
+}
+
+// Render mencetak semua kesalahan dengan baris kode sumber dan penanda ^, sama seperti `cok run`.
+func (e *SyntaxError) Render(w io.Writer) {
+	diagnostic.RenderAll(w, e.Src, e.Diagnostics)
+}
+
+// RuntimeError adalah kesalahan yang terjadi saat evaluasi, misalnya tipe yang tidak cocok.
+// Pos berisi posisi di kode sumber tempat kesalahan terjadi, atau kosong jika tidak diketahui.
+type RuntimeError struct {
+	Message string
+	Pos     token.Position
+}
+
+// newRuntimeError mengubah *object.Error menjadi *RuntimeError, atau *LimitExceeded jika
+// evaluasi dihentikan oleh batas eksekusi. env dipakai untuk mengambil penyebab pembatalan context.
+func newRuntimeError(err *object.Error, env *object.Environment) error {
+	runtimeErr := RuntimeError{Message: err.Message, Pos: err.Pos}
+	if err.Limit == "" {
+		return &runtimeErr
+	}
+
+	limitErr := &LimitExceeded{RuntimeError: runtimeErr, Limit: err.Limit}
+	if ctx := env.Budget().Context; err.Limit == object.CONTEXT_LIMIT && ctx != nil {
+		limitErr.cause = ctx.Err()
+	}
+	return limitErr
+}
+
+func (e *RuntimeError) Error() string {
+	if e.Pos.IsValid() {
+		return e.Pos.String() + ": " + e.Message
+	}
+	return e.Message
+}
+
+// LimitExceeded adalah kesalahan runtime karena batas eksekusi terlampaui: MaxSteps
+// (object.STEP_LIMIT), MaxDepth (object.DEPTH_LIMIT), atau pembatalan context dan Timeout
+// (object.CONTEXT_LIMIT). Untuk pembatalan context, errors.Is juga mengenali penyebabnya,
+// misalnya context.DeadlineExceeded.
+type LimitExceeded struct {
+	RuntimeError
+	Limit object.LimitKind
+	cause error
+}
+
+func (e *LimitExceeded) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,498 @@
+package cok
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-intepreter/object"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToObjectAndBack(t *testing.T) {
+	huge, _ := new(big.Int).SetString("99999999999999999999", 10)
+
+	tests := []struct {
+		input    any
+		expected any
+	}{
+		{nil, nil},
+		{true, true},
+		{false, false},
+		{42, int64(42)},
+		{int8(-3), int64(-3)},
+		{uint32(7), int64(7)},
+		{uint64(18446744073709551615), new(big.Int).SetUint64(18446744073709551615)},
+		{huge, huge},
+		{(*big.Int)(nil), nil},
+		{[]*big.Int{nil, big.NewInt(2)}, []any{nil, int64(2)}},
+		{1.5, 1.5},
+		{float32(0.25), 0.25},
+		{"héllo", "héllo"},
+		{[]any{1, "a", true, nil}, []any{int64(1), "a", true, nil}},
+		{[]string{"a", "b"}, []any{"a", "b"}},
+		{map[string]any{"a": 1, "b": []int{2}}, map[string]any{"a": int64(1), "b": []any{int64(2)}}},
+		{map[string]int{"x": 1}, map[string]any{"x": int64(1)}},
+	}
+
+	for _, tt := range tests {
+		obj, err := ToObject(tt.input)
+		if err != nil {
+			t.Errorf("ToObject(%#v) returned error: %s", tt.input, err)
+			continue
+		}
+
+		got := ToGo(obj)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ToGo(ToObject(%#v)) wrong. expected=%#v, got=%#v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestToObjectErrors(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected string
+	}{
+		{struct{}{}, "unsupported Go type struct {}"},
+		{map[int]string{1: "a"}, "unsupported map key type int, only string keys are supported"},
+		{[]any{1, make(chan int)}, "element 1: unsupported Go type chan int"},
+		{func(c chan int) {}, "parameter 1: unsupported Go type chan int"},
+		{GoFunc(nil), "function is nil"},
+		{[]any{GoFunc(nil)}, "element 0: function is nil"},
+	}
+
+	for _, tt := range tests {
+		_, err := ToObject(tt.input)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("ToObject(%T) wrong error. expected=%q, got=%v", tt.input, tt.expected, err)
+		}
+	}
+}
+
+func TestInterpreterKeepsBindings(t *testing.T) {
+	interp := NewInterpreter(Options{})
+
+	if _, err := interp.Eval("let x = 2;"); err != nil {
+		t.Fatal(err)
+	}
+	if err := interp.Set("y", 40); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := interp.Eval("x + y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != int64(42) {
+		t.Errorf("wrong result. expected=42, got=%#v", result)
+	}
+
+	if _, ok := interp.Get("missing"); ok {
+		t.Errorf("Get should report unbound names")
+	}
+	if _, ok := interp.Get("len"); ok {
+		t.Errorf("Get should not return builtins")
+	}
+}
+
+func TestInterpreterErrors(t *testing.T) {
+	interp := NewInterpreter(Options{Filename: "rules.cok"})
+
+	_, err := interp.Eval("let = 1;\nlet x 2;")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected *SyntaxError, got %T (%v)", err, err)
+	}
+	if err.Error() != "rules.cok:1:5: expected next token to be IDENT, got = instead (and 1 more)" {
+		t.Errorf("wrong syntax error. got=%q", err.Error())
+	}
+
+	_, err = interp.Eval("len(1, 2)")
+	var runtimeErr *RuntimeError
+	if !errors.As(err, &runtimeErr) {
+		t.Fatalf("expected *RuntimeError, got %T (%v)", err, err)
+	}
+	if err.Error() != "rules.cok:1:1: wrong number of arguments to `len`: got=2, want=1" {
+		t.Errorf("wrong runtime error. got=%q", err.Error())
+	}
+
+	if _, err := interp.Call("nothing"); err == nil || err.Error() != "identifier not found: nothing" {
+		t.Errorf("wrong error for unknown function. got=%v", err)
+	}
+	if _, err := interp.Call("len", struct{}{}); err == nil || err.Error() != "cok: argument 1 to len: unsupported Go type struct {}" {
+		t.Errorf("wrong error for unsupported argument. got=%v", err)
+	}
+}
+
+func TestCallbacksBetweenGoAndCok(t *testing.T) {
+	var out bytes.Buffer
+	interp := NewInterpreter(Options{Stdout: &out})
+
+	interp.Set("twice", func(args ...any) (any, error) {
+		f := args[0].(GoFunc)
+		once, err := f(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return f(once)
+	})
+
+	result, err := interp.Eval(`twice(fn(x) { puts(x); x * 3 }, 2)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != int64(18) {
+		t.Errorf("wrong result. expected=18, got=%#v", result)
+	}
+	if out.String() != "2\n6\n" {
+		t.Errorf("puts inside a callback should write to Options.Stdout. got=%q", out.String())
+	}
+
+	// builtin yang keluar ke Go tetap menulis ke Options.Stdout
+	out.Reset()
+	fns, err := interp.Eval(`[puts, len]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fns.([]any)[0].(GoFunc)("dari Go")
+	if out.String() != "dari Go\n" {
+		t.Errorf("puts from Eval result should write to Options.Stdout. got=%q", out.String())
+	}
+
+	if err := interp.Set("nothing", GoFunc(nil)); err == nil || err.Error() != "cok: cannot set nothing: function is nil" {
+		t.Errorf("Set should reject a nil GoFunc. got=%v", err)
+	}
+	if err := interp.Set("none", (*big.Int)(nil)); err != nil {
+		t.Errorf("Set of a nil *big.Int returned error: %s", err)
+	}
+	if none, _ := interp.Get("none"); none != nil {
+		t.Errorf("nil *big.Int should be bound as null. got=%#v", none)
+	}
+
+	// fungsi CokLang yang diambil dengan Get dapat dipanggil dari Go
+	interp.Eval("let add = fn(a, b) { a + b };")
+	add, _ := interp.Get("add")
+	sum, err := add.(GoFunc)(1, 2.5)
+	if err != nil || sum != 3.5 {
+		t.Errorf("wrong result from add. got=%#v, err=%v", sum, err)
+	}
+
+	if _, err := add.(GoFunc)(1); err == nil {
+		t.Errorf("expected arity error when calling add with one argument")
+	}
+}
+
+func TestRegisterFuncSignatures(t *testing.T) {
+	tests := []struct {
+		fn       any
+		expected string
+	}{
+		{nil, "cok: cannot register f: expected a function, got nil"},
+		{42, "cok: cannot register f: expected a function, got int"},
+		{(func())(nil), "cok: cannot register f: function is nil"},
+		{func(x complex128) {}, "cok: cannot register f: parameter 1: unsupported Go type complex128"},
+		{func(xs ...struct{}) {}, "cok: cannot register f: parameter 1: unsupported Go type struct {}"},
+		{func(m map[int]string) {}, "cok: cannot register f: parameter 1: unsupported map key type int, only string keys are supported"},
+		{func() (int, int) { return 0, 0 }, "cok: cannot register f: second result must be error, got int"},
+		{func() (int, int, error) { return 0, 0, nil }, "cok: cannot register f: function returns 3 values, want at most a value and an error"},
+		{func() chan int { return nil }, "cok: cannot register f: result: unsupported Go type chan int"},
+	}
+
+	for _, tt := range tests {
+		err := NewInterpreter(Options{}).RegisterFunc("f", tt.fn)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("RegisterFunc(%T) wrong error. expected=%q, got=%v", tt.fn, tt.expected, err)
+		}
+	}
+}
+
+type celsius float64
+
+func TestRegisterFuncCalls(t *testing.T) {
+	interp := NewInterpreter(Options{Filename: "host.cok"})
+
+	register := func(name string, fn any) {
+		if err := interp.RegisterFunc(name, fn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	register("repeat", func(s string, n int) (string, error) {
+		if n < 0 {
+			return "", errors.New("count must not be negative")
+		}
+		return strings.Repeat(s, n), nil
+	})
+	register("sum", func(xs ...float64) float64 {
+		total := 0.0
+		for _, x := range xs {
+			total += x
+		}
+		return total
+	})
+	register("byte", func(b uint8) uint8 { return b })
+	register("keys", func(m map[string]int) []string {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys
+	})
+	register("apply", func(f GoFunc, x any) (any, error) { return f(x) })
+	register("kind", func(obj object.Object) string { return string(obj.Type()) })
+	register("warm", func(c celsius) bool { return c > 20 })
+	register("nothing", func() {})
+	register("check", func(ok bool) error {
+		if !ok {
+			return errors.New("check failed")
+		}
+		return nil
+	})
+	register("big", func(n *big.Int) *big.Int { return new(big.Int).Mul(n, n) })
+	register("mk", func() GoFunc { return nil })
+	register("bigs", func() []*big.Int { return []*big.Int{nil} })
+
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`repeat("ab", 3)`, "ababab"},
+		{`sum()`, 0.0},
+		{`sum(1, 2.5, 3)`, 6.5},
+		{`byte(255)`, int64(255)},
+		{`keys({"b": 1, "a": 2})`, []any{"a", "b"}},
+		{`apply(fn(x) { x * 2 }, 21)`, int64(42)},
+		{`apply(len, "héllo")`, int64(5)},
+		{`kind([1])`, "ARRAY"},
+		{`warm(25.5)`, true},
+		{`nothing()`, nil},
+		{`check(true)`, nil},
+		{`mk()`, nil},
+		{`bigs()`, []any{nil}},
+		{`big(10000000000)`, new(big.Int).Mul(big.NewInt(10000000000), big.NewInt(10000000000))},
+	}
+
+	for _, tt := range tests {
+		result, err := interp.Eval(tt.input)
+		if err != nil {
+			t.Errorf("%s returned error: %s", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("%s wrong result. expected=%#v, got=%#v", tt.input, tt.expected, result)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`repeat("ab")`, "host.cok:1:1: wrong number of arguments to `repeat`: got=1, want=2"},
+		{"\n  repeat(\"ab\", -1)", "host.cok:2:3: count must not be negative"},
+		{`repeat(1, 2)`, "host.cok:1:1: argument 1 to `repeat` must be STRING, got INTEGER"},
+		{`repeat("ab", 1.5)`, "host.cok:1:1: argument 2 to `repeat` must be INTEGER, got FLOAT"},
+		{`repeat("ab", 99999999999999999999)`, "host.cok:1:1: argument 2 to `repeat` overflows int: 99999999999999999999"},
+		{`sum(1, "2")`, "host.cok:1:1: argument 2 to `sum` must be FLOAT, got STRING"},
+		{`byte(256)`, "host.cok:1:1: argument 1 to `byte` overflows uint8: 256"},
+		{`byte(-1)`, "host.cok:1:1: argument 1 to `byte` overflows uint8: -1"},
+		{`keys({"a": "x"})`, "host.cok:1:1: argument 1 to `keys` key \"a\" must be INTEGER, got STRING"},
+		{`keys({1: 1})`, "host.cok:1:1: argument 1 to `keys` has non-string key 1"},
+		{`apply(1, 2)`, "host.cok:1:1: argument 1 to `apply` must be FUNCTION, got INTEGER"},
+		{`apply(fn(x) { x + true }, 1)`, "host.cok:1:15: type mismatch: INTEGER + BOOLEAN"},
+		{`check(false)`, "host.cok:1:1: check failed"},
+		{"let g = mk();\ng(1)", "host.cok:2:1: not a function: NULL"},
+	}
+
+	for _, tt := range errorTests {
+		_, err := interp.Eval(tt.input)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("%s wrong error. expected=%q, got=%v", tt.input, tt.expected, err)
+		}
+	}
+}
+
+func TestSetWrapsPlainFunctions(t *testing.T) {
+	interp := NewInterpreter(Options{})
+	if err := interp.Set("add", func(a, b int) int { return a + b }); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := interp.Eval("add(2, 3)"); err != nil || result != int64(5) {
+		t.Errorf("wrong result. got=%#v, err=%v", result, err)
+	}
+	if _, err := interp.Eval("add(2)"); err == nil || err.Error() != "1:1: wrong number of arguments to `add`: got=1, want=2" {
+		t.Errorf("wrong error. got=%v", err)
+	}
+}
+
+const fibonacci = `
+let fibonacci = fn(x) {
+  if (x == 0) { 0 } else { if (x == 1) { 1 } else { fibonacci(x - 1) + fibonacci(x - 2) } }
+};`
+
+func TestExecutionLimits(t *testing.T) {
+	tests := []struct {
+		opts     Options
+		ctx      func() (context.Context, context.CancelFunc)
+		input    string
+		expected string
+		kind     object.LimitKind
+	}{
+		{
+			opts:     Options{MaxSteps: 10000},
+			input:    fibonacci + "fibonacci(30)",
+			expected: "step limit exceeded: more than 10000 evaluation steps",
+			kind:     object.STEP_LIMIT,
+		},
+		{
+			opts:     Options{MaxDepth: 50},
+			input:    "let down = fn(n) { down(n - 1) };\ndown(0)",
+			expected: "1:20: call depth limit exceeded: more than 50 nested calls",
+			kind:     object.DEPTH_LIMIT,
+		},
+		{
+			input:    "let down = fn(n) { down(n - 1) };\ndown(0)",
+			expected: "1:20: call depth limit exceeded: more than 10000 nested calls",
+			kind:     object.DEPTH_LIMIT,
+		},
+		{
+			opts:     Options{Timeout: 20 * time.Millisecond},
+			input:    fibonacci + "fibonacci(60)",
+			expected: "execution canceled: context deadline exceeded",
+			kind:     object.CONTEXT_LIMIT,
+		},
+		{
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			input:    fibonacci + "fibonacci(60)",
+			expected: "4:3: execution canceled: context canceled",
+			kind:     object.CONTEXT_LIMIT,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.ctx != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = tt.ctx()
+			defer cancel()
+		}
+
+		_, err := NewInterpreter(tt.opts).EvalContext(ctx, tt.input)
+		var limitErr *LimitExceeded
+		if !errors.As(err, &limitErr) {
+			t.Errorf("%+v: expected *LimitExceeded, got %T (%v)", tt.opts, err, err)
+			continue
+		}
+		if limitErr.Limit != tt.kind || !strings.HasSuffix(err.Error(), tt.expected) {
+			t.Errorf("%+v: wrong error. expected=%q (%s), got=%q (%s)", tt.opts, tt.expected, tt.kind, err, limitErr.Limit)
+		}
+	}
+}
+
+func TestLimitsApplyPerCall(t *testing.T) {
+	interp := NewInterpreter(Options{MaxSteps: 500, Timeout: time.Second})
+
+	if _, err := interp.Eval(fibonacci); err != nil {
+		t.Fatal(err)
+	}
+
+	// setiap Eval dan Call mendapat anggaran langkah yang baru
+	for n := 0; n < 3; n++ {
+		if result, err := interp.Call("fibonacci", 5); err != nil || result != int64(5) {
+			t.Fatalf("call %d: wrong result. got=%#v, err=%v", n, result, err)
+		}
+	}
+
+	_, err := interp.Call("fibonacci", 25)
+	if !errors.As(err, new(*LimitExceeded)) {
+		t.Fatalf("expected *LimitExceeded, got %T (%v)", err, err)
+	}
+
+	// fungsi yang diambil dengan Get juga mendapat anggaran baru di setiap pemanggilan,
+	// tanpa terkena context dari Eval sebelumnya
+	fib, _ := interp.Get("fibonacci")
+	for n := 0; n < 3; n++ {
+		if result, err := fib.(GoFunc)(5); err != nil || result != int64(5) {
+			t.Fatalf("GoFunc call %d: wrong result. got=%#v, err=%v", n, result, err)
+		}
+	}
+}
+
+func TestLimitsApplyToGoFuncs(t *testing.T) {
+	interp := NewInterpreter(Options{MaxSteps: 500})
+
+	fns, err := interp.Eval(fibonacci + "[fibonacci]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromEval := fns.([]any)[0].(GoFunc)
+	fromGet, _ := interp.Get("fibonacci")
+
+	for name, fn := range map[string]GoFunc{"Get": fromGet.(GoFunc), "Eval": fromEval} {
+		_, err := fn(25)
+		var limitErr *LimitExceeded
+		if !errors.As(err, &limitErr) || limitErr.Limit != object.STEP_LIMIT {
+			t.Errorf("GoFunc from %s: expected step *LimitExceeded, got %T (%v)", name, err, err)
+		}
+	}
+
+	// GoFunc yang dipanggil dari fungsi Go di tengah Eval memakai anggaran Eval tersebut,
+	// sehingga batas tidak dapat dihindari dengan memecah pekerjaan lewat kode Go
+	interp.RegisterFunc("call", func(f GoFunc, x any) (any, error) { return f(x) })
+	_, err = interp.Eval("call(fibonacci, 5) + call(fibonacci, 5) + call(fibonacci, 5)")
+	var limitErr *LimitExceeded
+	if !errors.As(err, &limitErr) || limitErr.Limit != object.STEP_LIMIT {
+		t.Errorf("expected step *LimitExceeded across callbacks, got %T (%v)", err, err)
+	}
+}
+
+func TestRecursionThroughGoFuncsIsBounded(t *testing.T) {
+	tests := []struct {
+		opts     Options
+		expected string
+	}{
+		{Options{MaxSteps: 1000, MaxDepth: 50}, "call depth limit exceeded: more than 50 nested calls"},
+		// tanpa Options tetap berhenti di object.DEFAULT_MAX_DEPTH, bukan stack overflow
+		{Options{}, "call depth limit exceeded: more than 10000 nested calls"},
+	}
+
+	for _, tt := range tests {
+		interp := NewInterpreter(tt.opts)
+		// rekursi yang seluruhnya berada di fungsi Go, tanpa badan fungsi CokLang
+		interp.RegisterFunc("y", func(f GoFunc) (any, error) { return f(f) })
+
+		_, err := interp.Eval("y(y)")
+
+		var limitErr *LimitExceeded
+		if !errors.As(err, &limitErr) || limitErr.Limit != object.DEPTH_LIMIT {
+			t.Fatalf("expected depth *LimitExceeded, got %T (%v)", err, err)
+		}
+		if limitErr.Message != tt.expected {
+			t.Errorf("wrong message. expected=%q, got=%q", tt.expected, limitErr.Message)
+		}
+	}
+}
+
+func TestLimitExceededSurvivesGoCallbacks(t *testing.T) {
+	interp := NewInterpreter(Options{Timeout: 20 * time.Millisecond})
+	interp.RegisterFunc("call", func(f GoFunc, x any) (any, error) { return f(x) })
+
+	_, err := interp.Eval(fibonacci + "call(fibonacci, 60)")
+
+	var limitErr *LimitExceeded
+	if !errors.As(err, &limitErr) || limitErr.Limit != object.CONTEXT_LIMIT {
+		t.Fatalf("expected context *LimitExceeded, got %T (%v)", err, err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("errors.Is should report context.DeadlineExceeded. got=%v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package cok
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-intepreter/evaluator"
+	"go-intepreter/object"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// GoFunc adalah bentuk fungsi Go yang dapat dipanggil dari CokLang tanpa reflection.
+// Argumen diubah dengan ToGo dan hasilnya dengan ToObject. Error yang dikembalikan
+// menjadi kesalahan runtime di CokLang.
+type GoFunc = func(args ...any) (any, error)
+
+// ToObject mengubah nilai Go menjadi object.Object:
+//
+//	nil                       -> null
+//	bool                      -> BOOLEAN
+//	int, int8 ... uint64      -> INTEGER (uint64 yang melebihi int64 menjadi big integer)
+//	*big.Int                  -> INTEGER (*big.Int bernilai nil menjadi null)
+//	float32, float64          -> FLOAT
+//	string                    -> STRING
+//	[]any dan slice lainnya   -> ARRAY
+//	map[string]any dan map lain dengan kunci string -> HASH (kunci diurutkan)
+//	GoFunc dan fungsi lain    -> BUILTIN (lihat RegisterFunc)
+//	object.Object             -> tidak diubah
+//
+// Tipe lain dan fungsi nil menghasilkan error.
+func ToObject(value any) (object.Object, error) {
+	return converter{}.toObject(value)
+}
+
+// converter mengubah nilai antara Go dan CokLang untuk satu Interpreter. Builtin yang diubah
+// menjadi GoFunc dipanggil dengan environment Interpreter tersebut, sehingga misalnya puts menulis
+// ke Options.Stdout. converter kosong, yang dipakai oleh ToObject dan ToGo, memakai environment baru.
+type converter struct {
+	interp *Interpreter
+}
+
+func (c converter) env() *object.Environment {
+	if c.interp != nil {
+		return c.interp.env
+	}
+	return object.NewEnvironment()
+}
+
+func (c converter) toObject(value any) (object.Object, error) {
+	switch v := value.(type) {
+	case nil:
+		return evaluator.NULL, nil
+	case object.Object:
+		return v, nil
+	case bool:
+		if v {
+			return evaluator.TRUE, nil
+		}
+		return evaluator.FALSE, nil
+	case int:
+		return &object.Integer{Value: int64(v)}, nil
+	case int8:
+		return &object.Integer{Value: int64(v)}, nil
+	case int16:
+		return &object.Integer{Value: int64(v)}, nil
+	case int32:
+		return &object.Integer{Value: int64(v)}, nil
+	case int64:
+		return &object.Integer{Value: v}, nil
+	case uint:
+		return object.NewInteger(new(big.Int).SetUint64(uint64(v))), nil
+	case uint8:
+		return &object.Integer{Value: int64(v)}, nil
+	case uint16:
+		return &object.Integer{Value: int64(v)}, nil
+	case uint32:
+		return &object.Integer{Value: int64(v)}, nil
+	case uint64:
+		return object.NewInteger(new(big.Int).SetUint64(v)), nil
+	case *big.Int:
+		if v == nil {
+			return evaluator.NULL, nil
+		}
+		return object.NewInteger(new(big.Int).Set(v)), nil
+	case float32:
+		return &object.Float{Value: float64(v)}, nil
+	case float64:
+		return &object.Float{Value: v}, nil
+	case string:
+		return &object.String{Value: v}, nil
+	case GoFunc:
+		if v == nil {
+			return nil, fmt.Errorf("function is nil")
+		}
+		return c.wrapGoFunc(v), nil
+	}
+
+	return c.reflectToObject(reflect.ValueOf(value))
+}
+
+// reflectToObject menangani tipe yang tidak disebut langsung di ToObject: tipe bernama dengan
+// dasar bool, bilangan atau string (misalnya time.Month), slice dan map seperti []string atau
+// map[string]int, serta fungsi Go biasa yang dibungkus seperti pada RegisterFunc.
+func (c converter) reflectToObject(v reflect.Value) (object.Object, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		return c.toObject(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.toObject(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.toObject(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return c.toObject(v.Float())
+	case reflect.String:
+		return c.toObject(v.String())
+	case reflect.Func:
+		return c.wrapFunc("func", v)
+
+	case reflect.Slice, reflect.Array:
+		elements := make([]object.Object, v.Len())
+		for i := range elements {
+			elem, err := c.toObject(v.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			elements[i] = elem
+		}
+		return &object.Array{Elements: elements}, nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s, only string keys are supported", v.Type().Key())
+		}
+
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		hash := object.NewHash()
+		for _, k := range keys {
+			val, err := c.toObject(v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", k, err)
+			}
+			hash.Set(&object.String{Value: k}, val)
+		}
+		return hash, nil
+	}
+
+	return nil, fmt.Errorf("unsupported Go type %s", v.Type())
+}
+
+// ToGo mengubah object.Object menjadi nilai Go:
+//
+//	INTEGER  -> int64 (atau *big.Int jika tidak muat di int64)
+//	FLOAT    -> float64
+//	BOOLEAN  -> bool
+//	STRING   -> string
+//	null     -> nil
+//	ARRAY    -> []any
+//	HASH     -> map[string]any (kunci non-string memakai Inspect, misalnya "1" atau "true")
+//	FUNCTION dan BUILTIN -> GoFunc
+//
+// Builtin yang diubah oleh ToGo dipanggil dengan environment baru, sehingga keluarannya ke os.Stdout.
+// Nilai dari Interpreter (Eval, Get, Call) memakai environment Interpreter tersebut.
+func ToGo(obj object.Object) any {
+	return converter{}.toGo(obj)
+}
+
+func (c converter) toGo(obj object.Object) any {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return obj.Value
+	case *object.BigInteger:
+		return new(big.Int).Set(obj.Value)
+	case *object.Float:
+		return obj.Value
+	case *object.Boolean:
+		return obj.Value
+	case *object.String:
+		return obj.Value
+	case *object.Array:
+		elements := make([]any, len(obj.Elements))
+		for i, e := range obj.Elements {
+			elements[i] = c.toGo(e)
+		}
+		return elements
+	case *object.Hash:
+		m := make(map[string]any, len(obj.Keys))
+		for _, pair := range obj.Ordered() {
+			m[hashKeyString(pair.Key)] = c.toGo(pair.Value)
+		}
+		return m
+	case *object.Function:
+		return c.goFuncFor(obj, obj.Env)
+	case *object.Builtin:
+		return c.goFuncFor(obj, c.env())
+	}
+	return nil
+}
+
+func hashKeyString(key object.Object) string {
+	if s, ok := key.(*object.String); ok {
+		return s.Value
+	}
+	return key.Inspect()
+}
+
+// goFuncFor membungkus fungsi CokLang agar dapat dipanggil dari Go. GoFunc dari Interpreter
+// dijalankan dengan batas Options Interpreter tersebut, sama seperti Call.
+func (c converter) goFuncFor(fn object.Object, env *object.Environment) GoFunc {
+	return func(args ...any) (any, error) {
+		if c.interp != nil {
+			defer c.interp.limit(context.Background())()
+		}
+
+		objs := make([]object.Object, len(args))
+		for i, arg := range args {
+			obj, err := c.toObject(arg)
+			if err != nil {
+				return nil, fmt.Errorf("cok: argument %d: %w", i+1, err)
+			}
+			objs[i] = obj
+		}
+
+		result := evaluator.CallFunction(fn, objs, env)
+		if errObj, ok := result.(*object.Error); ok {
+			return nil, newRuntimeError(errObj, env)
+		}
+		return c.toGo(result), nil
+	}
+}
+
+// wrapGoFunc membungkus GoFunc sebagai builtin CokLang.
+func (c converter) wrapGoFunc(fn GoFunc) *object.Builtin {
+	return &object.Builtin{Name: "func", Fn: func(env *object.Environment, args ...object.Object) object.Object {
+		goArgs := make([]any, len(args))
+		for i, arg := range args {
+			goArgs[i] = c.toGo(arg)
+		}
+
+		result, err := fn(goArgs...)
+		if err != nil {
+			return errorObject(err)
+		}
+
+		obj, err := c.toObject(result)
+		if err != nil {
+			return &object.Error{Message: fmt.Sprintf("cannot convert result: %s", err)}
+		}
+		return obj
+	}}
+}
+
+// errorObject mengubah error dari fungsi Go menjadi *object.Error. Kesalahan yang berasal dari
+// CokLang (misalnya dari GoFunc yang dipanggil di dalam fungsi Go) dikembalikan dengan posisi
+// dan tanda batas eksekusinya, sehingga tetap dapat dikenali setelah melewati kode Go.
+func errorObject(err error) *object.Error {
+	var limitErr *LimitExceeded
+	if errors.As(err, &limitErr) {
+		return &object.Error{Message: limitErr.Message, Pos: limitErr.Pos, Limit: limitErr.Limit}
+	}
+	var runtimeErr *RuntimeError
+	if errors.As(err, &runtimeErr) {
+		return &object.Error{Message: runtimeErr.Message, Pos: runtimeErr.Pos}
+	}
+	return &object.Error{Message: err.Error()}
+}
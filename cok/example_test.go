@@ -0,0 +1,137 @@
+package cok_test
+
+import (
+	"errors"
+	"fmt"
+	"go-intepreter/cok"
+	"os"
+	"strings"
+	"time"
+)
+
+func ExampleInterpreter_Eval() {
+	interp := cok.NewInterpreter(cok.Options{})
+
+	result, err := interp.Eval(`
+let discount = fn(total) { if (total > 100) { total / 10 } else { 0 } };
+discount(250);`)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(result)
+	// Output: 25
+}
+
+func ExampleInterpreter_Set() {
+	interp := cok.NewInterpreter(cok.Options{})
+
+	interp.Set("user", map[string]any{
+		"name":  "Siti",
+		"roles": []string{"admin", "editor"},
+	})
+
+	result, _ := interp.Eval(`"hello " + user["name"] + ", roles: " + type(user["roles"])`)
+	fmt.Println(result)
+	// Output: hello Siti, roles: ARRAY
+}
+
+func ExampleInterpreter_Get() {
+	interp := cok.NewInterpreter(cok.Options{})
+	interp.Eval(`let config = {"retries": 3, "ratio": 0.5, "hosts": ["a", "b"]};`)
+
+	config, ok := interp.Get("config")
+	fmt.Println(ok)
+	fmt.Printf("%v\n", config)
+	// Output:
+	// true
+	// map[hosts:[a b] ratio:0.5 retries:3]
+}
+
+func ExampleInterpreter_Call() {
+	interp := cok.NewInterpreter(cok.Options{})
+	interp.Eval(`let greet = fn(name, times) { if (times > 1) { name + "!" } else { name } };`)
+
+	result, err := interp.Call("greet", "cok", 2)
+	fmt.Println(result, err)
+	// Output: cok! <nil>
+}
+
+func ExampleInterpreter_Set_function() {
+	interp := cok.NewInterpreter(cok.Options{Stdout: os.Stdout})
+
+	interp.Set("upper", func(args ...any) (any, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, errors.New("upper expects a string")
+		}
+		return strings.ToUpper(s), nil
+	})
+
+	interp.Eval(`puts(upper("selamat pagi"));`)
+	_, err := interp.Eval(`upper(1)`)
+	fmt.Println(err)
+	// Output:
+	// SELAMAT PAGI
+	// 1:1: upper expects a string
+}
+
+func ExampleInterpreter_RegisterFunc() {
+	interp := cok.NewInterpreter(cok.Options{Filename: "rules.cok"})
+
+	interp.RegisterFunc("pad", func(s string, width int) (string, error) {
+		if width > 20 {
+			return "", fmt.Errorf("width %d is too large", width)
+		}
+		return fmt.Sprintf("%*s", width, s), nil
+	})
+
+	result, _ := interp.Eval(`pad("cok", 6)`)
+	fmt.Printf("%q\n", result)
+
+	_, err := interp.Eval(`pad("cok")`)
+	fmt.Println(err)
+	_, err = interp.Eval(`pad(1, 2)`)
+	fmt.Println(err)
+	_, err = interp.Eval(`pad("cok", 50)`)
+	fmt.Println(err)
+	// Output:
+	// "   cok"
+	// rules.cok:1:1: wrong number of arguments to `pad`: got=1, want=2
+	// rules.cok:1:1: argument 1 to `pad` must be STRING, got INTEGER
+	// rules.cok:1:1: width 50 is too large
+}
+
+func ExampleLimitExceeded() {
+	interp := cok.NewInterpreter(cok.Options{MaxSteps: 10000, Timeout: time.Second})
+	interp.Eval(`let loop = fn(n) { loop(n + 1) };`)
+
+	_, err := interp.Eval(`loop(0)`)
+
+	var limitErr *cok.LimitExceeded
+	if errors.As(err, &limitErr) {
+		fmt.Println(limitErr.Limit)
+		fmt.Println(err)
+	}
+	// Output:
+	// steps
+	// 1:29: step limit exceeded: more than 10000 evaluation steps
+}
+
+func ExampleSyntaxError() {
+	interp := cok.NewInterpreter(cok.Options{Filename: "rules.cok"})
+
+	_, err := interp.Eval("let x 5;")
+
+	var syntaxErr *cok.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		syntaxErr.Render(os.Stdout)
+	}
+	// Output:
+	// error[E001]: expected next token to be =, got INT instead
+	//  --> rules.cok:1:7
+	//   |
+	// 1 | let x 5;
+	//   |       ^ unexpected token
+	//   = hint: let bindings have the form `let <name> = <expression>;`
+}
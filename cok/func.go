@@ -0,0 +1,317 @@
+package cok
+
+import (
+	"fmt"
+	"go-intepreter/evaluator"
+	"go-intepreter/object"
+	"math/big"
+	"reflect"
+)
+
+var (
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	objectType = reflect.TypeOf((*object.Object)(nil)).Elem()
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+	goFuncType = reflect.TypeOf(GoFunc(nil))
+)
+
+// RegisterFunc mengikat fungsi Go biasa, misalnya func(a int, b string) (string, error),
+// sebagai builtin CokLang bernama name. Tanda tangan fn diperiksa saat pendaftaran:
+//
+//   - parameter boleh bertipe bool, bilangan bulat, float, string, *big.Int, any, object.Object,
+//     GoFunc, slice dari tipe tersebut, atau map dengan kunci string; parameter variadic didukung
+//   - hasil boleh kosong, satu nilai, satu error, atau satu nilai diikuti error
+//
+// Saat dipanggil, argumen diubah ke tipe parameter (INTEGER juga diterima untuk parameter float)
+// dan error yang dikembalikan fn menjadi kesalahan runtime di posisi pemanggilan. Jumlah atau tipe
+// argumen yang salah juga menjadi kesalahan runtime, dengan format yang sama seperti builtin lain.
+func (i *Interpreter) RegisterFunc(name string, fn any) error {
+	builtin, err := converter{i}.wrapFunc(name, reflect.ValueOf(fn))
+	if err != nil {
+		return fmt.Errorf("cok: cannot register %s: %w", name, err)
+	}
+	i.env.Set(name, builtin)
+	return nil
+}
+
+// wrapFunc memeriksa tanda tangan fn dan membungkusnya sebagai builtin. Fungsi pembungkus
+// membaca nama dari builtin itu sendiri, sehingga nama di pesan kesalahan ikut berubah jika
+// builtin diganti namanya oleh Set.
+func (c converter) wrapFunc(name string, fn reflect.Value) (*object.Builtin, error) {
+	if !fn.IsValid() || fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("expected a function, got %s", typeName(fn))
+	}
+	if fn.IsNil() {
+		return nil, fmt.Errorf("function is nil")
+	}
+
+	t := fn.Type()
+	for n := 0; n < t.NumIn(); n++ {
+		param := t.In(n)
+		if t.IsVariadic() && n == t.NumIn()-1 {
+			param = param.Elem()
+		}
+		if err := checkType(param); err != nil {
+			return nil, fmt.Errorf("parameter %d: %w", n+1, err)
+		}
+	}
+
+	switch {
+	case t.NumOut() > 2:
+		return nil, fmt.Errorf("function returns %d values, want at most a value and an error", t.NumOut())
+	case t.NumOut() == 2 && t.Out(1) != errorType:
+		return nil, fmt.Errorf("second result must be error, got %s", t.Out(1))
+	case t.NumOut() >= 1 && t.Out(0) != errorType:
+		if err := checkType(t.Out(0)); err != nil {
+			return nil, fmt.Errorf("result: %w", err)
+		}
+	}
+
+	builtin := &object.Builtin{Name: name}
+	builtin.Fn = func(env *object.Environment, args ...object.Object) object.Object {
+		if err := checkFuncArity(builtin.Name, t, len(args)); err != nil {
+			return err
+		}
+
+		in := make([]reflect.Value, len(args))
+		for n, arg := range args {
+			param := paramType(t, n)
+			v, err := c.fromObject(arg, param)
+			if err != nil {
+				return &object.Error{Message: fmt.Sprintf("argument %d to `%s` %s", n+1, builtin.Name, err)}
+			}
+			in[n] = v
+		}
+
+		return c.funcResult(builtin.Name, t, fn.Call(in))
+	}
+	return builtin, nil
+}
+
+// checkType memastikan t dapat diubah dari dan ke object.Object.
+func checkType(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	case reflect.Ptr:
+		if t == bigIntType {
+			return nil
+		}
+	case reflect.Interface:
+		if t.NumMethod() == 0 || t == objectType {
+			return nil
+		}
+	case reflect.Func:
+		if t == goFuncType {
+			return nil
+		}
+	case reflect.Slice:
+		if err := checkType(t.Elem()); err != nil {
+			return fmt.Errorf("unsupported Go type %s", t)
+		}
+		return nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s, only string keys are supported", t.Key())
+		}
+		if err := checkType(t.Elem()); err != nil {
+			return fmt.Errorf("unsupported Go type %s", t)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported Go type %s", t)
+}
+
+func checkFuncArity(name string, t reflect.Type, got int) *object.Error {
+	if t.IsVariadic() {
+		if want := t.NumIn() - 1; got < want {
+			return &object.Error{Message: fmt.Sprintf("wrong number of arguments to `%s`: got=%d, want at least %d", name, got, want)}
+		}
+		return nil
+	}
+	if want := t.NumIn(); got != want {
+		return &object.Error{Message: fmt.Sprintf("wrong number of arguments to `%s`: got=%d, want=%d", name, got, want)}
+	}
+	return nil
+}
+
+// paramType mengembalikan tipe parameter ke-n, dengan parameter variadic diperlakukan sebagai
+// elemen slice-nya.
+func paramType(t reflect.Type, n int) reflect.Type {
+	if t.IsVariadic() && n >= t.NumIn()-1 {
+		return t.In(t.NumIn() - 1).Elem()
+	}
+	return t.In(n)
+}
+
+// fromObject mengubah obj menjadi nilai Go bertipe t. Pesan kesalahannya ditulis sebagai
+// lanjutan dari "argument N to `name`", misalnya "must be INTEGER, got STRING".
+func (c converter) fromObject(obj object.Object, t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		b, ok := obj.(*object.Boolean)
+		if !ok {
+			return reflect.Value{}, mismatch(object.BOOLEAN_OBJ, obj)
+		}
+		return reflect.ValueOf(b.Value).Convert(t), nil
+
+	case reflect.String:
+		s, ok := obj.(*object.String)
+		if !ok {
+			return reflect.Value{}, mismatch(object.STRING_OBJ, obj)
+		}
+		return reflect.ValueOf(s.Value).Convert(t), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := obj.(*object.Integer)
+		if !ok {
+			if obj.Type() == object.INTEGER_OBJ {
+				return reflect.Value{}, fmt.Errorf("overflows %s: %s", t, obj.Inspect())
+			}
+			return reflect.Value{}, mismatch(object.INTEGER_OBJ, obj)
+		}
+		v := reflect.New(t).Elem()
+		if v.OverflowInt(n.Value) {
+			return reflect.Value{}, fmt.Errorf("overflows %s: %d", t, n.Value)
+		}
+		v.SetInt(n.Value)
+		return v, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n *big.Int
+		switch obj := obj.(type) {
+		case *object.Integer:
+			n = big.NewInt(obj.Value)
+		case *object.BigInteger:
+			n = obj.Value
+		default:
+			return reflect.Value{}, mismatch(object.INTEGER_OBJ, obj)
+		}
+		v := reflect.New(t).Elem()
+		if n.Sign() < 0 || !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+			return reflect.Value{}, fmt.Errorf("overflows %s: %s", t, n)
+		}
+		v.SetUint(n.Uint64())
+		return v, nil
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch obj := obj.(type) {
+		case *object.Float:
+			f = obj.Value
+		case *object.Integer:
+			f = float64(obj.Value)
+		case *object.BigInteger:
+			f, _ = new(big.Float).SetInt(obj.Value).Float64()
+		default:
+			return reflect.Value{}, mismatch(object.FLOAT_OBJ, obj)
+		}
+		return reflect.ValueOf(f).Convert(t), nil
+
+	case reflect.Ptr: // *big.Int
+		switch obj := obj.(type) {
+		case *object.Integer:
+			return reflect.ValueOf(big.NewInt(obj.Value)), nil
+		case *object.BigInteger:
+			return reflect.ValueOf(new(big.Int).Set(obj.Value)), nil
+		}
+		return reflect.Value{}, mismatch(object.INTEGER_OBJ, obj)
+
+	case reflect.Interface:
+		if t == objectType {
+			return reflect.ValueOf(&obj).Elem(), nil
+		}
+		v := reflect.New(t).Elem()
+		if goValue := c.toGo(obj); goValue != nil {
+			v.Set(reflect.ValueOf(goValue))
+		}
+		return v, nil
+
+	case reflect.Func: // GoFunc
+		if obj.Type() != object.FUNCTION_OBJ && obj.Type() != object.BUILTIN_OBJ {
+			return reflect.Value{}, mismatch(object.FUNCTION_OBJ, obj)
+		}
+		return reflect.ValueOf(c.toGo(obj)), nil
+
+	case reflect.Slice:
+		arr, ok := obj.(*object.Array)
+		if !ok {
+			return reflect.Value{}, mismatch(object.ARRAY_OBJ, obj)
+		}
+		v := reflect.MakeSlice(t, len(arr.Elements), len(arr.Elements))
+		for n, elem := range arr.Elements {
+			ev, err := c.fromObject(elem, t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d %w", n, err)
+			}
+			v.Index(n).Set(ev)
+		}
+		return v, nil
+
+	case reflect.Map:
+		hash, ok := obj.(*object.Hash)
+		if !ok {
+			return reflect.Value{}, mismatch(object.HASH_OBJ, obj)
+		}
+		v := reflect.MakeMapWithSize(t, len(hash.Keys))
+		for _, pair := range hash.Ordered() {
+			key, ok := pair.Key.(*object.String)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("has non-string key %s", pair.Key.Inspect())
+			}
+			ev, err := c.fromObject(pair.Value, t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("key %q %w", key.Value, err)
+			}
+			v.SetMapIndex(reflect.ValueOf(key.Value).Convert(t.Key()), ev)
+		}
+		return v, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("has unsupported Go type %s", t)
+}
+
+func typeName(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
+
+func mismatch(want object.ObjectType, got object.Object) error {
+	return fmt.Errorf("must be %s, got %s", want, got.Type())
+}
+
+// funcResult mengubah hasil pemanggilan reflect menjadi object.Object. Error yang tidak nil
+// menjadi *object.Error, dan posisinya diisi oleh evaluator dengan posisi pemanggilan.
+func (c converter) funcResult(name string, t reflect.Type, out []reflect.Value) object.Object {
+	if len(out) == 0 {
+		return evaluator.NULL
+	}
+
+	last := out[len(out)-1]
+	if t.Out(len(out)-1) == errorType {
+		if !last.IsNil() {
+			return errorObject(last.Interface().(error))
+		}
+		if len(out) == 1 {
+			return evaluator.NULL
+		}
+	}
+
+	result := out[0]
+	switch result.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Func:
+		if result.IsNil() {
+			return evaluator.NULL
+		}
+	}
+	obj, err := c.toObject(result.Interface())
+	if err != nil {
+		return &object.Error{Message: fmt.Sprintf("cannot convert result of `%s`: %s", name, err)}
+	}
+	return obj
+}
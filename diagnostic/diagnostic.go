@@ -0,0 +1,101 @@
+package diagnostic
+
+import (
+	"fmt"
+	"go-intepreter/token"
+)
+
+// Diagnostic adalah laporan terstruktur tentang masalah di dalam kode sumber.
+// Berbeda dengan pesan kesalahan berupa string biasa, Diagnostic menyimpan tingkat keparahan,
+// kode kesalahan dan rentang (span) posisi, sehingga alat lain (REPL, runner, editor)
+// dapat membedakan jenis kesalahan dan menandai lokasi yang tepat di kode sumber.
+type Diagnostic struct {
+	Severity Severity
+	Code     Code
+	Span     Span
+	Message  string
+	Hint     string // saran perbaikan, boleh kosong
+}
+
+// Span adalah rentang kode sumber dari Start sampai tepat sebelum End.
+type Span struct {
+	Start token.Position
+	End   token.Position
+}
+
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+	Note
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Note:
+		return "note"
+	default:
+		return "unknown"
+	}
+}
+
+// Code mengidentifikasi jenis kesalahan secara stabil, terlepas dari isi Message.
+type Code string
+
+const (
+	UnexpectedToken Code = "E001"
+	NoPrefixParseFn Code = "E002"
+	InvalidInteger  Code = "E003"
+	UnclosedBlock   Code = "E004"
+	InvalidFloat    Code = "E005"
+
+	// kesalahan leksikal
+	UnterminatedString  Code = "E101"
+	InvalidEscape       Code = "E102"
+	UnterminatedComment Code = "E103"
+	InvalidUTF8         Code = "E104"
+)
+
+var titles = map[Code]string{
+	UnexpectedToken: "unexpected token",
+	NoPrefixParseFn: "expected expression",
+	InvalidInteger:  "invalid integer literal",
+	UnclosedBlock:   "unclosed block",
+	InvalidFloat:    "invalid float literal",
+
+	UnterminatedString:  "unterminated string",
+	InvalidEscape:       "invalid escape sequence",
+	UnterminatedComment: "unterminated block comment",
+	InvalidUTF8:         "invalid UTF-8",
+}
+
+// Title mengembalikan deskripsi singkat dari kode kesalahan, misalnya "unexpected token" untuk E001.
+func (c Code) Title() string {
+	return titles[c]
+}
+
+// New membuat Diagnostic dengan tingkat Error.
+func New(code Code, span Span, format string, a ...interface{}) Diagnostic {
+	return Diagnostic{
+		Severity: Error,
+		Code:     code,
+		Span:     span,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+// String mengembalikan diagnostic dalam satu baris: posisi diikuti oleh pesan, misalnya
+// "main.cok:1:7: expected next token to be =, got INT instead".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Span.Start, d.Message)
+}
+
+// Error membuat Diagnostic memenuhi antarmuka error.
+func (d Diagnostic) Error() string {
+	return d.String()
+}
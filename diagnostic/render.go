@@ -0,0 +1,100 @@
+package diagnostic
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Render mencetak diagnostic dengan gaya rustc: header berisi tingkat dan kode kesalahan,
+// lokasi file:line:column, baris kode sumber yang bermasalah dan garis bawah ^ di bawah span.
+//
+//	error[E001]: expected next token to be =, got INT instead
+//	 --> main.cok:1:7
+//	  |
+//	1 | let x 5;
+//	  |       ^ unexpected token
+//	  = hint: ...
+func Render(w io.Writer, src string, d Diagnostic) {
+	fmt.Fprintf(w, "%s[%s]: %s\n", d.Severity, d.Code, d.Message)
+
+	start := d.Span.Start
+	if !start.IsValid() {
+		renderHint(w, "", d)
+		return
+	}
+
+	line, ok := sourceLine(src, start.Line)
+	gutter := strings.Repeat(" ", len(fmt.Sprint(start.Line)))
+
+	fmt.Fprintf(w, "%s--> %s\n", gutter, start)
+	if !ok {
+		renderHint(w, gutter, d)
+		return
+	}
+
+	fmt.Fprintf(w, "%s |\n", gutter)
+	fmt.Fprintf(w, "%d | %s\n", start.Line, line)
+	fmt.Fprintf(w, "%s | %s%s", gutter, padding(line, start.Column), strings.Repeat("^", underlineWidth(line, d.Span)))
+	if title := d.Code.Title(); title != "" {
+		fmt.Fprintf(w, " %s", title)
+	}
+	fmt.Fprintln(w)
+
+	renderHint(w, gutter, d)
+}
+
+// RenderAll mencetak setiap diagnostic dengan Render, dipisahkan oleh baris kosong.
+func RenderAll(w io.Writer, src string, diags []Diagnostic) {
+	for i, d := range diags {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		Render(w, src, d)
+	}
+}
+
+func renderHint(w io.Writer, gutter string, d Diagnostic) {
+	if d.Hint != "" {
+		fmt.Fprintf(w, "%s = hint: %s\n", gutter, d.Hint)
+	}
+}
+
+// sourceLine mengembalikan baris ke-n (dimulai dari 1) dari src tanpa karakter baris baru.
+func sourceLine(src string, n int) (string, bool) {
+	lines := strings.Split(src, "\n")
+	if n < 1 || n > len(lines) {
+		return "", false
+	}
+	return strings.TrimRight(lines[n-1], "\r"), true
+}
+
+// padding menghasilkan spasi sebanyak rune sebelum column (column dihitung dalam rune).
+// Tab dipertahankan agar tanda ^ tetap sejajar dengan kode sumber di terminal.
+func padding(line string, column int) string {
+	var out strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			out.WriteRune(r)
+		} else {
+			out.WriteRune(' ')
+		}
+	}
+	return out.String()
+}
+
+// underlineWidth menghitung jumlah ^ yang dicetak. Span yang melewati satu baris
+// digarisbawahi sampai akhir baris, dan span kosong tetap mendapat satu ^.
+func underlineWidth(line string, span Span) int {
+	width := 1
+	if span.End.Line == span.Start.Line && span.End.Column > span.Start.Column {
+		width = span.End.Column - span.Start.Column
+	} else if n := utf8.RuneCountInString(line); span.End.Line > span.Start.Line && n-span.Start.Column+1 > 1 {
+		width = n - span.Start.Column + 1
+	}
+	return width
+}
@@ -0,0 +1,82 @@
+package diagnostic
+
+import (
+	"bytes"
+	"go-intepreter/token"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	src := "let x = 5;\nlet y 10;\n"
+	d := New(UnexpectedToken, Span{
+		Start: token.Position{File: "main.cok", Line: 2, Column: 7, Offset: 17},
+		End:   token.Position{File: "main.cok", Line: 2, Column: 9, Offset: 19},
+	}, "expected next token to be =, got INT instead")
+	d.Hint = "let bindings have the form `let <name> = <expression>;`"
+
+	var out bytes.Buffer
+	Render(&out, src, d)
+
+	expected := `error[E001]: expected next token to be =, got INT instead
+ --> main.cok:2:7
+  |
+2 | let y 10;
+  |       ^^ unexpected token
+  = hint: let bindings have the form ` + "`let <name> = <expression>;`" + `
+`
+	if out.String() != expected {
+		t.Errorf("wrong render.\nexpected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestRenderKeepsTabsAligned(t *testing.T) {
+	src := "\tx +;"
+	d := New(NoPrefixParseFn, Span{
+		Start: token.Position{Line: 1, Column: 5},
+		End:   token.Position{Line: 1, Column: 6},
+	}, "no prefix parse function for ; found")
+
+	var out bytes.Buffer
+	Render(&out, src, d)
+
+	expected := "error[E002]: no prefix parse function for ; found\n" +
+		" --> 1:5\n" +
+		"  |\n" +
+		"1 | \tx +;\n" +
+		"  | \t   ^ expected expression\n"
+	if out.String() != expected {
+		t.Errorf("wrong render.\nexpected:\n%q\ngot:\n%q", expected, out.String())
+	}
+}
+
+func TestRenderWithoutPosition(t *testing.T) {
+	d := New(UnexpectedToken, Span{}, "something went wrong")
+
+	var out bytes.Buffer
+	Render(&out, "", d)
+
+	expected := "error[E001]: something went wrong\n"
+	if out.String() != expected {
+		t.Errorf("wrong render. expected=%q, got=%q", expected, out.String())
+	}
+}
+
+func TestRenderCountsColumnsInRunes(t *testing.T) {
+	src := "let café = \"ñ\" +;"
+	d := New(NoPrefixParseFn, Span{
+		Start: token.Position{Line: 1, Column: 17},
+		End:   token.Position{Line: 1, Column: 18},
+	}, "no prefix parse function for ; found")
+
+	var out bytes.Buffer
+	Render(&out, src, d)
+
+	expected := "error[E002]: no prefix parse function for ; found\n" +
+		" --> 1:17\n" +
+		"  |\n" +
+		"1 | let café = \"ñ\" +;\n" +
+		"  |                 ^ expected expression\n"
+	if out.String() != expected {
+		t.Errorf("wrong render.\nexpected:\n%q\ngot:\n%q", expected, out.String())
+	}
+}
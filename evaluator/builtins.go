@@ -0,0 +1,157 @@
+package evaluator
+
+import (
+	"fmt"
+	"go-intepreter/object"
+	"sort"
+	"unicode/utf8"
+)
+
+// builtins adalah registry fungsi bawaan, dikunci berdasarkan nama pengenal.
+// evalIdentifier mencari nama di sini setelah environment, sehingga program tetap dapat
+// membayangi nama builtin dengan let (misalnya let len = 5;).
+//
+// Setiap builtin memeriksa jumlah dan tipe argumennya sendiri dan mengembalikan *object.Error
+// jika tidak sesuai. Posisi pemanggilan ditambahkan oleh applyFunction.
+var builtins = map[string]*object.Builtin{}
+
+func init() {
+	register("len", builtinLen)
+	register("puts", builtinPuts)
+	register("first", builtinFirst)
+	register("last", builtinLast)
+	register("rest", builtinRest)
+	register("push", builtinPush)
+	register("type", builtinType)
+}
+
+func register(name string, fn object.BuiltinFunction) {
+	builtins[name] = &object.Builtin{Name: name, Fn: fn}
+}
+
+// BuiltinNames mengembalikan nama semua fungsi bawaan, diurutkan secara alfabetis.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// len mengembalikan jumlah karakter (rune) sebuah string, jumlah elemen array,
+// atau jumlah pasangan hash.
+func builtinLen(env *object.Environment, args ...object.Object) object.Object {
+	if err := checkArity("len", args, 1); err != nil {
+		return err
+	}
+
+	switch arg := args[0].(type) {
+	case *object.String:
+		return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
+	case *object.Array:
+		return &object.Integer{Value: int64(len(arg.Elements))}
+	case *object.Hash:
+		return &object.Integer{Value: int64(len(arg.Keys))}
+	default:
+		return newError("argument to `len` not supported, got %s", arg.Type())
+	}
+}
+
+// puts mencetak setiap argumen pada barisnya sendiri ke env.Output() dan mengembalikan null.
+func builtinPuts(env *object.Environment, args ...object.Object) object.Object {
+	out := env.Output()
+	for _, arg := range args {
+		fmt.Fprintln(out, arg.Inspect())
+	}
+	return NULL
+}
+
+// first mengembalikan elemen pertama array, atau null jika array kosong.
+func builtinFirst(env *object.Environment, args ...object.Object) object.Object {
+	arr, err := arrayArgument("first", args)
+	if err != nil {
+		return err
+	}
+
+	if len(arr.Elements) == 0 {
+		return NULL
+	}
+	return arr.Elements[0]
+}
+
+// last mengembalikan elemen terakhir array, atau null jika array kosong.
+func builtinLast(env *object.Environment, args ...object.Object) object.Object {
+	arr, err := arrayArgument("last", args)
+	if err != nil {
+		return err
+	}
+
+	if len(arr.Elements) == 0 {
+		return NULL
+	}
+	return arr.Elements[len(arr.Elements)-1]
+}
+
+// rest mengembalikan array baru tanpa elemen pertama, atau null jika array kosong.
+// Array aslinya tidak diubah.
+func builtinRest(env *object.Environment, args ...object.Object) object.Object {
+	arr, err := arrayArgument("rest", args)
+	if err != nil {
+		return err
+	}
+
+	length := len(arr.Elements)
+	if length == 0 {
+		return NULL
+	}
+
+	elements := make([]object.Object, length-1)
+	copy(elements, arr.Elements[1:])
+	return &object.Array{Elements: elements}
+}
+
+// push mengembalikan array baru dengan elemen tambahan di akhir. Array aslinya tidak diubah.
+func builtinPush(env *object.Environment, args ...object.Object) object.Object {
+	if err := checkArity("push", args, 2); err != nil {
+		return err
+	}
+
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return newError("first argument to `push` must be ARRAY, got %s", args[0].Type())
+	}
+
+	length := len(arr.Elements)
+	elements := make([]object.Object, length+1)
+	copy(elements, arr.Elements)
+	elements[length] = args[1]
+	return &object.Array{Elements: elements}
+}
+
+// type mengembalikan nama tipe argumen sebagai string, misalnya "INTEGER" atau "FUNCTION".
+func builtinType(env *object.Environment, args ...object.Object) object.Object {
+	if err := checkArity("type", args, 1); err != nil {
+		return err
+	}
+	return &object.String{Value: string(args[0].Type())}
+}
+
+func checkArity(name string, args []object.Object, want int) *object.Error {
+	if len(args) != want {
+		return newError("wrong number of arguments to `%s`: got=%d, want=%d", name, len(args), want)
+	}
+	return nil
+}
+
+func arrayArgument(name string, args []object.Object) (*object.Array, *object.Error) {
+	if err := checkArity(name, args, 1); err != nil {
+		return nil, err
+	}
+
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, newError("argument to `%s` must be ARRAY, got %s", name, args[0].Type())
+	}
+	return arr, nil
+}
@@ -0,0 +1,722 @@
+package evaluator
+
+import (
+	"fmt"
+	"go-intepreter/ast"
+	"go-intepreter/object"
+	"math"
+	"math/big"
+)
+
+// Evaluator adalah bagian "E" dari REPL. Ia menerima AST yang dihasilkan oleh parser
+// dan menjalankannya secara langsung dengan cara berjalan (tree-walking) menelusuri setiap node.
+// Setiap node dievaluasi menjadi sebuah object.Object.
+
+// Karena hanya ada satu nilai true, satu nilai false dan satu nilai null,
+// kita cukup membuat satu instance untuk masing-masing dan mereferensikannya kembali,
+// daripada mengalokasikan object.Boolean atau object.Null baru setiap kali.
+var (
+	NULL  = &object.Null{}
+	TRUE  = &object.Boolean{Value: true}
+	FALSE = &object.Boolean{Value: false}
+)
+
+// Eval mengambil ast.Node dan mengembalikan object.Object hasil evaluasinya.
+// Environment menyimpan pengikatan yang dibuat oleh pernyataan let, serta Budget yang membatasi
+// jumlah langkah evaluasi (setiap node adalah satu langkah) dan kedalaman pemanggilan.
+func Eval(node ast.Node, env *object.Environment) object.Object {
+	if err := step(node, env.Budget()); err != nil {
+		return err
+	}
+
+	switch node := node.(type) {
+
+	// Statements
+	case *ast.Program:
+		return evalProgram(node, env)
+
+	case *ast.ExpressionStatement:
+		return Eval(node.Expression, env)
+
+	case *ast.BlockStatement:
+		return evalBlockStatement(node, env)
+
+	case *ast.ReturnStatement:
+		val := Eval(node.ReturnValue, env)
+		if isAbrupt(val) {
+			return val
+		}
+		return &object.ReturnValue{Value: val}
+
+	case *ast.LetStatement:
+		val := Eval(node.Value, env)
+		if isAbrupt(val) {
+			return val
+		}
+		env.Set(node.Name.Value, val)
+
+	// Expressions
+	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return &object.BigInteger{Value: node.Big}
+		}
+		return &object.Integer{Value: node.Value}
+
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
+
+	case *ast.Boolean:
+		return nativeBoolToBooleanObject(node.Value)
+
+	case *ast.StringLiteral:
+		return &object.String{Value: node.Value}
+
+	case *ast.PrefixExpression:
+		right := Eval(node.Right, env)
+		if isAbrupt(right) {
+			return right
+		}
+		return atNode(node, evalPrefixExpression(node.Operator, right))
+
+	case *ast.InfixExpression:
+		left := Eval(node.Left, env)
+		if isAbrupt(left) {
+			return left
+		}
+		right := Eval(node.Right, env)
+		if isAbrupt(right) {
+			return right
+		}
+		return atNode(node, evalInfixExpression(node.Operator, left, right))
+
+	case *ast.IfExpression:
+		return evalIfExpression(node, env)
+
+	case *ast.ArrayLiteral:
+		elements := evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isAbrupt(elements[0]) {
+			return elements[0]
+		}
+		return &object.Array{Elements: elements}
+
+	case *ast.HashLiteral:
+		return evalHashLiteral(node, env)
+
+	case *ast.IndexExpression:
+		left := Eval(node.Left, env)
+		if isAbrupt(left) {
+			return left
+		}
+		index := Eval(node.Index, env)
+		if isAbrupt(index) {
+			return index
+		}
+		return atNode(node, evalIndexExpression(left, index))
+
+	case *ast.Identifier:
+		return atNode(node, evalIdentifier(node, env))
+
+	case *ast.FunctionLiteral:
+		return &object.Function{Parameters: node.Parameters, Body: node.Body, Env: env}
+
+	case *ast.CallExpression:
+		function := Eval(node.Function, env)
+		if isAbrupt(function) {
+			return function
+		}
+		args := evalExpressions(node.Arguments, env)
+		if len(args) == 1 && isAbrupt(args[0]) {
+			return args[0]
+		}
+		return applyFunction(node, function, args, env)
+
+	// Placeholder dari parser untuk kode yang tidak dapat diurai
+	case *ast.BadStatement:
+		return newError("cannot evaluate invalid statement at %s", node.Pos())
+
+	case *ast.BadExpression:
+		return newError("cannot evaluate invalid expression at %s", node.Pos())
+	}
+
+	return nil
+}
+
+// evalProgram mengevaluasi setiap pernyataan secara berurutan.
+// Jika menemukan ReturnValue, evaluasi dihentikan dan nilai yang dibungkusnya dikembalikan.
+// Jika menemukan Error, error tersebut langsung dikembalikan.
+func evalProgram(program *ast.Program, env *object.Environment) object.Object {
+	var result object.Object
+
+	for _, statement := range program.Statements {
+		result = Eval(statement, env)
+
+		switch result := result.(type) {
+		case *object.ReturnValue:
+			return result.Value
+		case *object.Error:
+			return result
+		}
+	}
+
+	return result
+}
+
+// evalBlockStatement mirip dengan evalProgram, tetapi ReturnValue tidak dibuka bungkusnya.
+// Dengan begitu return di dalam blok bersarang tetap menghentikan evaluasi sampai ke blok terluar.
+func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+	var result object.Object
+
+	for _, statement := range block.Statements {
+		result = Eval(statement, env)
+
+		if result != nil {
+			rt := result.Type()
+			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+// evalIfExpression mengevaluasi konsekuensi jika kondisinya "truthy", alternatif jika ada,
+// dan NULL jika kondisinya tidak terpenuhi dan tidak ada cabang else.
+func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+	condition := Eval(ie.Condition, env)
+	if isAbrupt(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(ie.Consequence, env)
+	} else if ie.Alternative != nil {
+		return Eval(ie.Alternative, env)
+	} else {
+		return NULL
+	}
+}
+
+// Nilai selain null dan false dianggap truthy, termasuk 0.
+func isTruthy(obj object.Object) bool {
+	switch obj {
+	case NULL:
+		return false
+	case TRUE:
+		return true
+	case FALSE:
+		return false
+	default:
+		return true
+	}
+}
+
+func nativeBoolToBooleanObject(input bool) *object.Boolean {
+	if input {
+		return TRUE
+	}
+	return FALSE
+}
+
+func evalPrefixExpression(operator string, right object.Object) object.Object {
+	switch operator {
+	case "!":
+		return evalBangOperatorExpression(right)
+	case "-":
+		return evalMinusPrefixOperatorExpression(right)
+	default:
+		return newError("unknown operator: %s%s", operator, right.Type())
+	}
+}
+
+// !true -> false, !false -> true, !null -> true.
+// Nilai lainnya (misalnya !5) dianggap "truthy", sehingga hasilnya false.
+func evalBangOperatorExpression(right object.Object) object.Object {
+	switch right {
+	case TRUE:
+		return FALSE
+	case FALSE:
+		return TRUE
+	case NULL:
+		return TRUE
+	default:
+		return FALSE
+	}
+}
+
+func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		if right.Value == math.MinInt64 {
+			return object.NewInteger(new(big.Int).Neg(big.NewInt(right.Value)))
+		}
+		return &object.Integer{Value: -right.Value}
+	case *object.BigInteger:
+		return object.NewInteger(new(big.Int).Neg(right.Value))
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	default:
+		return newError("unknown operator: -%s", right.Type())
+	}
+}
+
+// Untuk operator == dan != pada boolean kita cukup membandingkan pointer,
+// karena hanya ada dua instance object.Boolean: TRUE dan FALSE.
+//
+// Aritmetika antara INTEGER dan INTEGER menghasilkan INTEGER (pembagian dibulatkan ke arah nol: 7 / 2 = 3).
+// Jika salah satu operan adalah FLOAT, operan INTEGER diubah menjadi float dan hasilnya FLOAT (7 / 2.0 = 3.5).
+// Perbandingan antar angka dilakukan berdasarkan nilainya, sehingga 1 == 1.0 bernilai true.
+func evalInfixExpression(operator string, left, right object.Object) object.Object {
+	switch {
+	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
+		return evalIntegerInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		return evalStringInfixExpression(operator, left, right)
+	case left.Type() != right.Type():
+		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+	case operator == "==":
+		return nativeBoolToBooleanObject(left == right)
+	case operator == "!=":
+		return nativeBoolToBooleanObject(left != right)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalIntegerInfixExpression menghitung dengan int64 selama hasilnya muat. Jika sebuah operasi
+// akan overflow (misalnya faktorial 21), perhitungan diulang dengan math/big sehingga hasilnya
+// tetap benar, bukan berputar menjadi angka negatif. Lihat juga evalBigIntegerInfixExpression.
+func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+	leftInt, leftOk := left.(*object.Integer)
+	rightInt, rightOk := right.(*object.Integer)
+	if !leftOk || !rightOk {
+		return evalBigIntegerInfixExpression(operator, left, right)
+	}
+
+	leftVal := leftInt.Value
+	rightVal := rightInt.Value
+
+	switch operator {
+	case "+":
+		if sum := leftVal + rightVal; (sum > leftVal) == (rightVal > 0) {
+			return &object.Integer{Value: sum}
+		}
+	case "-":
+		if diff := leftVal - rightVal; (diff < leftVal) == (rightVal > 0) {
+			return &object.Integer{Value: diff}
+		}
+	case "*":
+		if leftVal == 0 || rightVal == 0 {
+			return &object.Integer{Value: 0}
+		}
+		product := leftVal * rightVal
+		if product/rightVal == leftVal && !(leftVal == -1 && rightVal == math.MinInt64) && !(rightVal == -1 && leftVal == math.MinInt64) {
+			return &object.Integer{Value: product}
+		}
+	case "/":
+		if rightVal == 0 {
+			return newError("division by zero: %d / %d", leftVal, rightVal)
+		}
+		if !(leftVal == math.MinInt64 && rightVal == -1) {
+			return &object.Integer{Value: leftVal / rightVal}
+		}
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	// hasil int64 overflow
+	return evalBigIntegerInfixExpression(operator, left, right)
+}
+
+// evalBigIntegerInfixExpression menghitung dengan math/big. Hasilnya dikembalikan melalui
+// object.NewInteger, jadi kembali menjadi *object.Integer jika muat di int64.
+// Pembagian dibulatkan ke arah nol, sama seperti pembagian int64.
+func evalBigIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := toBig(left)
+	rightVal := toBig(right)
+
+	switch operator {
+	case "+":
+		return object.NewInteger(new(big.Int).Add(leftVal, rightVal))
+	case "-":
+		return object.NewInteger(new(big.Int).Sub(leftVal, rightVal))
+	case "*":
+		return object.NewInteger(new(big.Int).Mul(leftVal, rightVal))
+	case "/":
+		if rightVal.Sign() == 0 {
+			return newError("division by zero: %s / %s", left.Inspect(), right.Inspect())
+		}
+		return object.NewInteger(new(big.Int).Quo(leftVal, rightVal))
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func toBig(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInteger:
+		return obj.Value
+	}
+	return new(big.Int)
+}
+
+// evalFloatInfixExpression menangani operasi yang melibatkan setidaknya satu FLOAT.
+// Sama seperti integer, pembagian dengan nol menghasilkan error, bukan +Inf.
+func evalFloatInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := toFloat(left)
+	rightVal := toFloat(right)
+
+	switch operator {
+	case "+":
+		return &object.Float{Value: leftVal + rightVal}
+	case "-":
+		return &object.Float{Value: leftVal - rightVal}
+	case "*":
+		return &object.Float{Value: leftVal * rightVal}
+	case "/":
+		if rightVal == 0 {
+			return newError("division by zero: %s / %s", left.Inspect(), right.Inspect())
+		}
+		return &object.Float{Value: leftVal / rightVal}
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	return obj.Type() == object.INTEGER_OBJ || obj.Type() == object.FLOAT_OBJ
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.Float:
+		return obj.Value
+	case *object.BigInteger:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return f
+	}
+	return 0
+}
+
+// String mendukung penggabungan dengan + dan perbandingan leksikografis (byte demi byte)
+// dengan ==, !=, < dan >. String dibandingkan berdasarkan isinya, bukan berdasarkan pointer.
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := left.(*object.String).Value
+	rightVal := right.(*object.String).Value
+
+	switch operator {
+	case "+":
+		return &object.String{Value: leftVal + rightVal}
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalExpressions mengevaluasi ekspresi dari kiri ke kanan. Jika salah satunya menghasilkan Error
+// atau ReturnValue, evaluasi dihentikan dan hanya objek tersebut yang dikembalikan.
+func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
+	var result []object.Object
+
+	for _, e := range exps {
+		evaluated := Eval(e, env)
+		if isAbrupt(evaluated) {
+			return []object.Object{evaluated}
+		}
+		result = append(result, evaluated)
+	}
+
+	return result
+}
+
+func evalIndexExpression(left, index object.Object) object.Object {
+	switch {
+	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.HASH_OBJ:
+		return evalHashIndexExpression(left, index)
+	default:
+		return newError("index operator not supported: %s[%s]", left.Type(), index.Type())
+	}
+}
+
+// Indeks negatif dihitung dari akhir array: arr[-1] adalah elemen terakhir.
+// Indeks di luar rentang [-len, len-1] menghasilkan error, bukan null.
+func evalArrayIndexExpression(array, index object.Object) object.Object {
+	arrayObject := array.(*object.Array)
+	length := int64(len(arrayObject.Elements))
+
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		// BigInteger selalu di luar rentang array
+		return newError("index out of range: %s (array length %d)", index.Inspect(), length)
+	}
+	idx := integer.Value
+
+	i := idx
+	if i < 0 {
+		i += length
+	}
+
+	if i < 0 || i >= length {
+		return newError("index out of range: %d (array length %d)", idx, length)
+	}
+
+	return arrayObject.Elements[i]
+}
+
+// evalHashLiteral mengevaluasi setiap pasangan sesuai urutan di kode sumber.
+// Kunci harus memenuhi object.Hashable; kunci lain (misalnya array) menghasilkan error.
+func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
+	hash := object.NewHash()
+
+	for _, pair := range node.Pairs {
+		key := Eval(pair.Key, env)
+		if isAbrupt(key) {
+			return key
+		}
+
+		hashKey, ok := key.(object.Hashable)
+		if !ok {
+			return atNode(pair.Key, newError("unusable as hash key: %s", key.Type()))
+		}
+
+		value := Eval(pair.Value, env)
+		if isAbrupt(value) {
+			return value
+		}
+
+		hash.Set(hashKey, value)
+	}
+
+	return hash
+}
+
+// Mengakses kunci yang tidak ada menghasilkan NULL.
+func evalHashIndexExpression(hash, index object.Object) object.Object {
+	hashObject := hash.(*object.Hash)
+
+	key, ok := index.(object.Hashable)
+	if !ok {
+		return newError("unusable as hash key: %s", index.Type())
+	}
+
+	value, ok := hashObject.Get(key)
+	if !ok {
+		return NULL
+	}
+
+	return value
+}
+
+func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
+	if val, ok := env.Get(node.Value); ok {
+		return val
+	}
+
+	if builtin, ok := builtins[node.Value]; ok {
+		return builtin
+	}
+
+	return newError("identifier not found: %s", node.Value)
+}
+
+// applyFunction memanggil fungsi dengan argumen yang sudah dievaluasi. Badan fungsi dievaluasi
+// di environment baru yang diapit oleh environment tempat fungsi didefinisikan (bukan tempat
+// fungsi dipanggil), sehingga nama-nama bebas di dalam fungsi mengikuti lingkup leksikal.
+// Builtin dipanggil dengan environment pemanggil.
+//
+// Kesalahan dari pemanggilan itu sendiri (bukan fungsi, jumlah argumen salah, atau argumen
+// builtin yang tidak valid) diberi posisi call site. Kesalahan dari dalam badan fungsi
+// diteruskan apa adanya.
+func applyFunction(call *ast.CallExpression, fn object.Object, args []object.Object, env *object.Environment) object.Object {
+	budget := env.Budget()
+	if err := checkContext(budget); err != nil {
+		return atCallSite(call, err)
+	}
+
+	switch fn := fn.(type) {
+	case *object.Function:
+		if len(args) != len(fn.Parameters) {
+			return atCallSite(call, newError("wrong number of arguments: want=%d, got=%d", len(fn.Parameters), len(args)))
+		}
+
+		if err := enterCall(budget); err != nil {
+			return atCallSite(call, err)
+		}
+		defer func() { budget.Depth-- }()
+
+		extendedEnv := extendFunctionEnv(fn, args)
+		evaluated := Eval(fn.Body, extendedEnv)
+		return unwrapReturnValue(evaluated)
+
+	case *object.Builtin:
+		// builtin juga dihitung, karena fungsi Go dapat memanggil kembali CokLang atau dirinya
+		// sendiri (melalui GoFunc) tanpa melewati badan fungsi CokLang
+		if err := enterCall(budget); err != nil {
+			return atCallSite(call, err)
+		}
+		defer func() { budget.Depth-- }()
+
+		result := fn.Fn(env, args...)
+		if err, ok := result.(*object.Error); ok {
+			return atCallSite(call, err)
+		}
+		if result == nil {
+			return NULL
+		}
+		return result
+
+	default:
+		return atCallSite(call, newError("not a function: %s", fn.Type()))
+	}
+}
+
+// atCallSite mengisi posisi err dengan posisi pemanggilan jika belum ada.
+// call bernilai nil jika fungsi dipanggil dari Go melalui CallFunction.
+func atCallSite(call *ast.CallExpression, err *object.Error) *object.Error {
+	if call != nil && !err.Pos.IsValid() {
+		err.Pos = call.Pos()
+	}
+	return err
+}
+
+// atNode mengisi posisi kesalahan yang dihasilkan oleh node itu sendiri (misalnya tipe yang tidak
+// cocok pada operator infiks) dengan posisi node. Kesalahan dari sub-ekspresi sudah membawa posisinya
+// sendiri dan diteruskan sebelum sampai di sini.
+func atNode(node ast.Node, obj object.Object) object.Object {
+	if err, ok := obj.(*object.Error); ok && !err.Pos.IsValid() {
+		err.Pos = node.Pos()
+	}
+	return obj
+}
+
+// CallFunction memanggil fn (sebuah *object.Function atau *object.Builtin) dari kode Go dengan
+// argumen yang sudah berupa object.Object. env dipakai sebagai environment pemanggil untuk builtin.
+// Hasilnya sama seperti ekspresi pemanggilan di CokLang, termasuk *object.Error jika gagal.
+func CallFunction(fn object.Object, args []object.Object, env *object.Environment) object.Object {
+	return applyFunction(nil, fn, args, env)
+}
+
+func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
+	env := object.NewEnclosedEnvironment(fn.Env)
+
+	for i, param := range fn.Parameters {
+		env.Set(param.Value, args[i])
+	}
+
+	return env
+}
+
+// unwrapReturnValue membuka bungkus ReturnValue agar return di dalam fungsi hanya menghentikan
+// fungsi tersebut, bukan seluruh program. Fungsi dengan badan kosong mengembalikan NULL.
+func unwrapReturnValue(obj object.Object) object.Object {
+	if returnValue, ok := obj.(*object.ReturnValue); ok {
+		return returnValue.Value
+	}
+	if obj == nil {
+		return NULL
+	}
+	return obj
+}
+
+// step menghitung satu langkah evaluasi. Setelah MaxSteps terlampaui, setiap langkah berikutnya
+// juga gagal, sehingga kesalahan tidak dapat "ditelan" oleh kode yang terus berjalan.
+func step(node ast.Node, budget *object.Budget) *object.Error {
+	budget.Steps++
+	if budget.MaxSteps > 0 && budget.Steps > budget.MaxSteps {
+		err := limitError(object.STEP_LIMIT, "step limit exceeded: more than %d evaluation steps", budget.MaxSteps)
+		if node != nil {
+			err.Pos = node.Pos()
+		}
+		return err
+	}
+	return nil
+}
+
+// enterCall menambah kedalaman pemanggilan. Pemanggil wajib mengurangi Depth setelah fungsi selesai.
+func enterCall(budget *object.Budget) *object.Error {
+	maxDepth := budget.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = object.DEFAULT_MAX_DEPTH
+	}
+	if budget.Depth >= maxDepth {
+		return limitError(object.DEPTH_LIMIT, "call depth limit exceeded: more than %d nested calls", maxDepth)
+	}
+	budget.Depth++
+	return nil
+}
+
+// checkContext memeriksa pembatalan atau tenggat waktu Context. Pemeriksaan dilakukan pada setiap
+// pemanggilan fungsi; karena CokLang tidak memiliki perulangan selain rekursi, setiap eksekusi
+// yang berjalan lama pasti melewati titik ini.
+func checkContext(budget *object.Budget) *object.Error {
+	if budget.Context == nil {
+		return nil
+	}
+	if err := budget.Context.Err(); err != nil {
+		return limitError(object.CONTEXT_LIMIT, "execution canceled: %s", err)
+	}
+	return nil
+}
+
+func limitError(kind object.LimitKind, format string, a ...interface{}) *object.Error {
+	err := newError(format, a...)
+	err.Limit = kind
+	return err
+}
+
+func newError(format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...)}
+}
+
+func isError(obj object.Object) bool {
+	if obj != nil {
+		return obj.Type() == object.ERROR_OBJ
+	}
+	return false
+}
+
+// isAbrupt melaporkan apakah obj harus menghentikan evaluasi ekspresi yang memakainya: sebuah Error,
+// atau ReturnValue dari return di dalam if yang dipakai sebagai nilai (let x = if (c) { return 5; }).
+// Keduanya diteruskan apa adanya sampai ke badan fungsi atau program, seperti pada evalBlockStatement.
+func isAbrupt(obj object.Object) bool {
+	return isError(obj) || (obj != nil && obj.Type() == object.RETURN_VALUE_OBJ)
+}
@@ -0,0 +1,924 @@
+package evaluator
+
+import (
+	"bytes"
+	"context"
+	"go-intepreter/lexer"
+	"go-intepreter/object"
+	"go-intepreter/parser"
+	"math"
+	"testing"
+)
+
+func TestEvalIntegerExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"5", 5},
+		{"10", 10},
+		{"-5", -5},
+		{"-10", -10},
+		{"5 + 5 + 5 + 5 - 10", 10},
+		{"2 * 2 * 2 * 2 * 2", 32},
+		{"-50 + 100 + -50", 0},
+		{"5 * 2 + 10", 20},
+		{"5 + 2 * 10", 25},
+		{"20 + 2 * -10", 0},
+		{"50 / 2 * 2 + 10", 60},
+		{"3 * 3 * 3 + 10", 37},
+		{"3 * (3 * 3) + 10", 37},
+		{"(5 + 10 * 2 + 15 / 3) * 2 + -10", 50},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testIntegerObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestEvalFloatExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.5", 3.5},
+		{"-2.5", -2.5},
+		{"1e3", 1000},
+		{"0.1 + 0.2 * 2", 0.5},
+		{"1 + 0.5", 1.5},
+		{"0.5 + 1", 1.5},
+		{"7 / 2.0", 3.5},
+		{"7.0 / 2", 3.5},
+		{"2 * 1.5 - 1", 2},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testFloatObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestMixedNumberRules(t *testing.T) {
+	// pembagian dua integer tetap integer
+	testIntegerObject(t, testEval("7 / 2"), 3)
+	testIntegerObject(t, testEval("-7 / 2"), -3)
+	testIntegerObject(t, testEval("0xff + 0b1 + 0o7 + 1_000"), 1263)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1 == 1.0", true},
+		{"1.5 != 1", true},
+		{"1 < 1.5", true},
+		{"2.5 > 3", false},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+
+	inspects := map[string]string{
+		"1.5 * 2":  "3.0",
+		"1e21 * 1": "1e+21",
+		"0.1":      "0.1",
+		"-0.5":     "-0.5",
+	}
+	for input, expected := range inspects {
+		if got := testEval(input).Inspect(); got != expected {
+			t.Errorf("%s: wrong Inspect. expected=%q, got=%q", input, expected, got)
+		}
+	}
+}
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 1 - 1", "-9223372036854775809"},
+		{"-(-9223372036854775807 - 1)", "9223372036854775808"},
+		{"(-9223372036854775807 - 1) / -1", "9223372036854775808"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"99999999999999999999", "99999999999999999999"},
+		{"99999999999999999999 * 99999999999999999999", "9999999999999999999800000000000000000001"},
+		{"99999999999999999999 / 7", "14285714285714285714"},
+		{"-99999999999999999999 / 7", "-14285714285714285714"},
+		// 1*2*...*25
+		{"1*2*3*4*5*6*7*8*9*10*11*12*13*14*15*16*17*18*19*20*21*22*23*24*25", "15511210043330985984000000"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		big, ok := evaluated.(*object.BigInteger)
+		if !ok {
+			t.Errorf("%s: object is not BigInteger. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if big.Inspect() != tt.expected {
+			t.Errorf("%s: wrong value. expected=%s, got=%s", tt.input, tt.expected, big.Inspect())
+		}
+		if big.Type() != object.INTEGER_OBJ {
+			t.Errorf("%s: BigInteger must report type INTEGER. got=%s", tt.input, big.Type())
+		}
+	}
+
+	// hasil yang kembali muat di int64 menjadi Integer biasa
+	testIntegerObject(t, testEval("99999999999999999999 - 99999999999999999990"), 9)
+	testIntegerObject(t, testEval("9223372036854775808 / 2"), 4611686018427387904)
+	testBooleanObject(t, testEval("99999999999999999999 > 9223372036854775807"), true)
+	testBooleanObject(t, testEval("99999999999999999999 == 99999999999999999999"), true)
+	testFloatObject(t, testEval("99999999999999999999 * 1.0"), 1e20)
+}
+
+func TestEvalBooleanExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1 < 2", true},
+		{"1 > 2", false},
+		{"1 < 1", false},
+		{"1 == 1", true},
+		{"1 != 1", false},
+		{"1 == 2", false},
+		{"1 != 2", true},
+		{"1 < 2 == 2 > 1", true},
+		{"1 < 2 != 2 > 1", false},
+		{"true", true},
+		{"false", false},
+		{"true == true", true},
+		{"false == false", true},
+		{"true == false", false},
+		{"true != false", true},
+		{"(1 < 2) == true", true},
+		{"(1 > 2) == true", false},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testBooleanObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestBangOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"!5", false},
+		{"!!5", true},
+		{"!!!5", false},
+		{"!true", false},
+		{"!false", true},
+		{"!!true", true},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testBooleanObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"return 10;", 10},
+		{"return 10; 9;", 10},
+		{"return 2 * 5; 9;", 10},
+		{"9; return 2 * 5; 9;", 10},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testIntegerObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let a = 5; a;", 5},
+		{"let a = 5 * 5; a;", 25},
+		{"let a = 5; let b = a; b;", 5},
+		{"let a = 5; let b = a; let c = a + b + 5; c;", 15},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestIfElseExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"if (true) { 10 }", 10},
+		{"if (false) { 10 }", nil},
+		{"if (1) { 10 }", 10},
+		{"if (1 < 2) { 10 }", 10},
+		{"if (1 > 2) { 10 }", nil},
+		{"if (1 > 2) { 10 } else { 20 }", 20},
+		{"if (1 < 2) { 10 } else { 20 }", 10},
+		{"let x = 1; if (x == 0) { 0 } else if (x == 1) { 1 } else { 2 }", 1},
+		{"let x = 5; if (x == 0) { 0 } else if (x == 1) { 1 } else { 2 }", 2},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestNestedReturnStatements(t *testing.T) {
+	input := `
+if (10 > 1) {
+  if (10 > 1) {
+    return 10;
+  }
+
+  return 1;
+}
+`
+	testIntegerObject(t, testEval(input), 10)
+}
+
+func TestReturnInsideIfUsedAsValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		// let
+		{"let x = if (true) { return 5; }; 10", 5},
+		{"let f = fn() { let x = if (true) { return 5; }; 10 }; f()", 5},
+		// argumen pemanggilan, termasuk builtin
+		{"len(if (true) { return 5; }); 10", 5},
+		{"let f = fn() { len(if (true) { return 5; }) + 10 }; f()", 5},
+		{"let id = fn(x) { x }; let f = fn() { id(1, if (true) { return 5; }) }; f()", 5},
+		// fungsi yang dipanggil
+		{"let f = fn() { (if (true) { return 5; })(1); 10 }; f()", 5},
+		// elemen array
+		{"let f = fn() { [1, if (true) { return 5; }, 3]; 10 }; f()", 5},
+		// operan infiks dan prefiks
+		{"let f = fn() { (if (true) { return 5; }) + 1 }; f()", 5},
+		{"let f = fn() { 1 + if (true) { return 5; } }; f()", 5},
+		{"let f = fn() { -if (true) { return 5; } }; f()", 5},
+		// indeks dan hash
+		{"let f = fn() { [1, 2][if (true) { return 5; }] }; f()", 5},
+		{"let f = fn() { {if (true) { return 5; }: 1} }; f()", 5},
+		{`let f = fn() { {"a": if (true) { return 5; }} }; f()`, 5},
+		// kondisi if
+		{"let f = fn() { if (if (true) { return 5; }) { 1 } else { 2 } }; f()", 5},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if !testIntegerObject(t, evaluated, tt.expected) {
+			t.Errorf("input: %s", tt.input)
+		}
+	}
+}
+
+func TestStringLiteral(t *testing.T) {
+	evaluated := testEval(`"Hello\tWorld!\n"`)
+	testStringObject(t, evaluated, "Hello\tWorld!\n")
+}
+
+func TestStringConcatenation(t *testing.T) {
+	evaluated := testEval(`let name = "Cok"; "Hello" + " " + name + "!"`)
+	testStringObject(t, evaluated, "Hello Cok!")
+}
+
+func TestStringComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`"a" == "a"`, true},
+		{`"a" == "b"`, false},
+		{`"a" != "b"`, true},
+		{`"abc" < "abd"`, true},
+		{`"b" > "abc"`, true},
+		{`"a" + "b" == "ab"`, true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestArrayLiterals(t *testing.T) {
+	evaluated := testEval("[1, 2 * 2, 3 + 3]")
+
+	result, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if len(result.Elements) != 3 {
+		t.Fatalf("array has wrong num of elements. got=%d", len(result.Elements))
+	}
+
+	testIntegerObject(t, result.Elements[0], 1)
+	testIntegerObject(t, result.Elements[1], 4)
+	testIntegerObject(t, result.Elements[2], 6)
+
+	if result.Inspect() != "[1, 4, 6]" {
+		t.Errorf("wrong Inspect. got=%q", result.Inspect())
+	}
+}
+
+func TestArrayIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"[1, 2, 3][0]", 1},
+		{"[1, 2, 3][1]", 2},
+		{"[1, 2, 3][2]", 3},
+		{"let i = 0; [1][i];", 1},
+		{"[1, 2, 3][1 + 1];", 3},
+		{"let myArray = [1, 2, 3]; myArray[2];", 3},
+		{"let myArray = [1, 2, 3]; myArray[0] + myArray[1] + myArray[2];", 6},
+		{"let myArray = [1, 2, 3]; let i = myArray[0]; myArray[i]", 2},
+		{"[1, 2, 3][-1]", 3},
+		{"[1, 2, 3][-3]", 1},
+		{"[[1, 2], [3, 4]][1][0]", 3},
+		{"[1, 2, 3][3]", "index out of range: 3 (array length 3)"},
+		{"[1, 2, 3][-4]", "index out of range: -4 (array length 3)"},
+		{"[][0]", "index out of range: 0 (array length 0)"},
+		{`[1, 2, 3]["a"]`, "index operator not supported: ARRAY[STRING]"},
+		{"1[0]", "index operator not supported: INTEGER[INTEGER]"},
+		{"[1, foo, 3]", "identifier not found: foo"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			testErrorObject(t, evaluated, expected)
+		}
+	}
+}
+
+func TestHashLiterals(t *testing.T) {
+	input := `let two = "two";
+	{
+		"one": 10 - 9,
+		two: 1 + 1,
+		"thr" + "ee": 6 / 2,
+		4: 4,
+		true: 5,
+		false: 6
+	}`
+
+	evaluated := testEval(input)
+	result, ok := evaluated.(*object.Hash)
+	if !ok {
+		t.Fatalf("Eval didn't return Hash. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	expected := []struct {
+		key   object.Hashable
+		value int64
+	}{
+		{&object.String{Value: "one"}, 1},
+		{&object.String{Value: "two"}, 2},
+		{&object.String{Value: "three"}, 3},
+		{&object.Integer{Value: 4}, 4},
+		{TRUE, 5},
+		{FALSE, 6},
+	}
+
+	if len(result.Pairs) != len(expected) {
+		t.Fatalf("Hash has wrong num of pairs. got=%d", len(result.Pairs))
+	}
+
+	for i, e := range expected {
+		pair, ok := result.Pairs[e.key.HashKey()]
+		if !ok {
+			t.Errorf("no pair for given key in Pairs")
+		}
+		testIntegerObject(t, pair.Value, e.value)
+
+		if result.Keys[i] != e.key.HashKey() {
+			t.Errorf("key %d is out of insertion order", i)
+		}
+	}
+
+	expectedInspect := "{one: 1, two: 2, three: 3, 4: 4, true: 5, false: 6}"
+	if result.Inspect() != expectedInspect {
+		t.Errorf("wrong Inspect. expected=%q, got=%q", expectedInspect, result.Inspect())
+	}
+}
+
+func TestHashIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{"foo": 5}["foo"]`, 5},
+		{`{"foo": 5}["bar"]`, nil},
+		{`let key = "foo"; {"foo": 5}[key]`, 5},
+		{`{}["foo"]`, nil},
+		{`{5: 5}[5]`, 5},
+		{`{true: 5}[true]`, 5},
+		{`{false: 5}[false]`, 5},
+		{`{1: 5}[true]`, nil},
+		{`let thorsten = {"name": "Thorsten", "age": 28}; thorsten["age"]`, 28},
+		{`{"a": 1, "a": 2}["a"]`, 2},
+		{`{[1]: 2}`, "unusable as hash key: ARRAY"},
+		{`{"a": 1}[[1]]`, "unusable as hash key: ARRAY"},
+		{`{{}: 1}`, "unusable as hash key: HASH"},
+		{`{fn(x) { x }: 1}`, "unusable as hash key: FUNCTION"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			testErrorObject(t, evaluated, expected)
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestErrorHandling(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"foobar", "identifier not found: foobar"},
+		{"-!5", "unknown operator: -BOOLEAN"},
+		{"5 + !5", "type mismatch: INTEGER + BOOLEAN"},
+		{"1 < 2 == 5", "type mismatch: BOOLEAN == INTEGER"},
+		{"!5 + !5", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"5 + true; 5;", "type mismatch: INTEGER + BOOLEAN"},
+		{"-true", "unknown operator: -BOOLEAN"},
+		{"true + false;", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"if (10 > 1) { true + false; }", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"if (10 > 1) { if (10 > 1) { return true + false; } return 1; }", "unknown operator: BOOLEAN + BOOLEAN"},
+		{`"Hello" - "World"`, "unknown operator: STRING - STRING"},
+		{`"a" + 1`, "type mismatch: STRING + INTEGER"},
+		{"let x = ;", "cannot evaluate invalid expression at 1:9"},
+		{"let = 5;", "cannot evaluate invalid statement at 1:1"},
+		{"10 / 0", "division by zero: 10 / 0"},
+		{"1.5 / 0", "division by zero: 1.5 / 0"},
+		{"1 / 0.0", "division by zero: 1 / 0.0"},
+		{"99999999999999999999 / 0", "division by zero: 99999999999999999999 / 0"},
+		{"[1][99999999999999999999]", "index out of range: 99999999999999999999 (array length 1)"},
+		{"5(1)", "not a function: INTEGER"},
+		{`let f = "fn"; f()`, "not a function: STRING"},
+		{"fn(x, y) { x + y }(1)", "wrong number of arguments: want=2, got=1"},
+		{"fn() { 1 }(1, 2)", "wrong number of arguments: want=0, got=2"},
+		{"let f = fn() { missing }; f()", "identifier not found: missing"},
+		{"fn(x) { x }(1 + true)", "type mismatch: INTEGER + BOOLEAN"},
+		{"1.5 + true", "type mismatch: FLOAT + BOOLEAN"},
+		{`"a" * 1.5`, "type mismatch: STRING * FLOAT"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestFunctionObject(t *testing.T) {
+	input := "fn(x) { x + 2; };"
+
+	evaluated := testEval(input)
+	fn, ok := evaluated.(*object.Function)
+	if !ok {
+		t.Fatalf("object is not Function. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if len(fn.Parameters) != 1 {
+		t.Fatalf("function has wrong parameters. Parameters=%+v", fn.Parameters)
+	}
+
+	if fn.Parameters[0].String() != "x" {
+		t.Fatalf("parameter is not 'x'. got=%q", fn.Parameters[0])
+	}
+
+	expectedBody := "{ (x + 2) }"
+	if fn.Body.String() != expectedBody {
+		t.Fatalf("body is not %q. got=%q", expectedBody, fn.Body.String())
+	}
+
+	if fn.Inspect() != "fn(x) { (x + 2) }" {
+		t.Errorf("wrong Inspect. got=%q", fn.Inspect())
+	}
+}
+
+func TestFunctionApplication(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let identity = fn(x) { x; }; identity(5);", 5},
+		{"let identity = fn(x) { return x; }; identity(5);", 5},
+		{"let double = fn(x) { x * 2; }; double(5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5, 5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5 + 5, add(5, 5));", 20},
+		{"fn(x) { x; }(5)", 5},
+		{"let early = fn(x) { if (x > 1) { return 1; } return 2; }; early(5) + early(0);", 3},
+		{"let f = fn() { return 10; 20; }; f(); 7", 7},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+
+	testNullObject(t, testEval("fn() {}()"))
+	testNullObject(t, testEval("fn(x) { let y = x; }(1)"))
+}
+
+func TestClosures(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+	}{
+		{"adder", `
+let newAdder = fn(x) {
+  fn(y) { x + y };
+};
+let addTwo = newAdder(2);
+addTwo(2);`, 4},
+		{"adders are independent", `
+let newAdder = fn(x) { fn(y) { x + y } };
+let addOne = newAdder(1);
+let addTen = newAdder(10);
+addOne(1) * 100 + addTen(1);`, 211},
+		{"counter", `
+let counter = fn(n) {
+  {"value": n, "next": fn() { counter(n + 1) }}
+};
+let c = counter(0)["next"]()["next"]()["next"]();
+c["value"];`, 3},
+		{"currying", `
+let curry = fn(f) { fn(a) { fn(b) { f(a, b) } } };
+let multiply = curry(fn(x, y) { x * y });
+let triple = multiply(3);
+triple(4) + multiply(2)(5);`, 22},
+		{"higher-order", `
+let twice = fn(f) { fn(x) { f(f(x)) } };
+let addThree = fn(x) { x + 3 };
+twice(twice(addThree))(1);`, 13},
+		{"lexical scope", `
+let x = 1;
+let getX = fn() { x };
+let callWithX = fn(x) { getX() };
+callWithX(100);`, 1},
+		{"recursion", `
+let fibonacci = fn(x) {
+  if (x == 0) { 0 } else { if (x == 1) { 1 } else { fibonacci(x - 1) + fibonacci(x - 2) } }
+};
+fibonacci(15);`, 610},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testIntegerObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestShadowing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		// parameter membayangi nama di luar
+		{"let x = 1; let f = fn(x) { x * 10 }; f(5) + x", 51},
+		// let di dalam fungsi tidak mengubah nama di luar
+		{"let x = 1; let f = fn() { let x = 2; x }; f() * 10 + x", 21},
+		{"let x = 1; let f = fn(y) { let x = x + y; x }; f(5) * 10 + x", 61},
+		// fungsi bersarang melihat lingkup terdekat terlebih dahulu
+		{"let x = 1; let f = fn(x) { fn(x) { x } }; f(2)(3)", 3},
+		{"let x = 1; let f = fn(x) { fn(y) { x + y } }; f(2)(3)", 5},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestRecursionWithBigIntegers(t *testing.T) {
+	input := `
+let factorial = fn(n) { if (n < 2) { 1 } else { n * factorial(n - 1) } };
+factorial(25);`
+
+	evaluated := testEval(input)
+	if evaluated.Inspect() != "15511210043330985984000000" {
+		t.Errorf("wrong factorial(25). got=%s", evaluated.Inspect())
+	}
+}
+
+func TestBuiltinFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`len("")`, 0},
+		{`len("four")`, 4},
+		{`len("hello world")`, 11},
+		{`len("héllo")`, 5},
+		{`len([1, 2, 3])`, 3},
+		{`len({"a": 1, "b": 2})`, 2},
+		{`len(1)`, "argument to `len` not supported, got INTEGER"},
+		{`len("one", "two")`, "wrong number of arguments to `len`: got=2, want=1"},
+		{`first([1, 2, 3])`, 1},
+		{`first([])`, nil},
+		{`first(1)`, "argument to `first` must be ARRAY, got INTEGER"},
+		{`last([1, 2, 3])`, 3},
+		{`last([])`, nil},
+		{`last()`, "wrong number of arguments to `last`: got=0, want=1"},
+		{`rest([1, 2, 3])`, []int64{2, 3}},
+		{`rest([1])`, []int64{}},
+		{`rest([])`, nil},
+		{`push([], 1)`, []int64{1}},
+		{`let a = [1]; let b = push(a, 2); len(a) * 10 + len(b)`, 12},
+		{`push(1, 1)`, "first argument to `push` must be ARRAY, got INTEGER"},
+		{`type(1)`, "INTEGER"},
+		{`type(99999999999999999999)`, "INTEGER"},
+		{`type(1.5)`, "FLOAT"},
+		{`type(fn() {})`, "FUNCTION"},
+		{`type(len)`, "BUILTIN"},
+		{`type({})`, "HASH"},
+		{`let len = fn(x) { 42 }; len([1])`, 42},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			testNullObject(t, evaluated)
+		case string:
+			switch obj := evaluated.(type) {
+			case *object.Error:
+				if obj.Message != expected {
+					t.Errorf("%s: wrong error message. expected=%q, got=%q", tt.input, expected, obj.Message)
+				}
+			case *object.String:
+				if obj.Value != expected {
+					t.Errorf("%s: wrong string. expected=%q, got=%q", tt.input, expected, obj.Value)
+				}
+			default:
+				t.Errorf("%s: expected error or string, got=%T (%+v)", tt.input, evaluated, evaluated)
+			}
+		case []int64:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("%s: obj not Array. got=%T (%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+			if len(array.Elements) != len(expected) {
+				t.Errorf("%s: wrong number of elements. want=%d, got=%d", tt.input, len(expected), len(array.Elements))
+				continue
+			}
+			for i, expectedElem := range expected {
+				testIntegerObject(t, array.Elements[i], expectedElem)
+			}
+		}
+	}
+}
+
+func TestBuiltinErrorsCarryCallSite(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let xs = [1];\nlet n = len(xs, xs);", "ERROR: 2:9: wrong number of arguments to `len`: got=2, want=1"},
+		{"first(1)", "ERROR: 1:1: argument to `first` must be ARRAY, got INTEGER"},
+		{"let f = fn(x) { x };\n  f()", "ERROR: 2:3: wrong number of arguments: want=1, got=0"},
+		{"let f = fn(x) { len(x, x) };\nf(1)", "ERROR: 1:17: wrong number of arguments to `len`: got=2, want=1"},
+		{"5()", "ERROR: 1:1: not a function: INTEGER"},
+		// kesalahan dari dalam badan fungsi memakai posisinya sendiri, bukan call site
+		{"let f = fn(x) { x + true };\nf(1)", "ERROR: 1:17: type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		if got := testEval(tt.input).Inspect(); got != tt.expected {
+			t.Errorf("%q: wrong error. expected=%q, got=%q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestRuntimeErrorsCarryNodePosition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 + true", "ERROR: 1:1: type mismatch: INTEGER + BOOLEAN"},
+		{"let a = 1;\n  -\"x\"", "ERROR: 2:3: unknown operator: -STRING"},
+		{"let x = 1;\nx + missing", "ERROR: 2:5: identifier not found: missing"},
+		{"[1, 2][5]", "ERROR: 1:1: index out of range: 5 (array length 2)"},
+		{"{\"a\": 1}[[1]]", "ERROR: 1:1: unusable as hash key: ARRAY"},
+		{"let h = {1: 1,\n [2]: 2}", "ERROR: 2:2: unusable as hash key: ARRAY"},
+		{"1[0]", "ERROR: 1:1: index operator not supported: INTEGER[INTEGER]"},
+		// kesalahan dari sub-ekspresi tetap memakai posisi sub-ekspresi tersebut
+		{"1 + (2 * \"a\")", "ERROR: 1:6: type mismatch: INTEGER * STRING"},
+	}
+
+	for _, tt := range tests {
+		if got := testEval(tt.input).Inspect(); got != tt.expected {
+			t.Errorf("%q: wrong error. expected=%q, got=%q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestPutsWritesToEnvironmentOutput(t *testing.T) {
+	var out bytes.Buffer
+	env := object.NewEnvironment()
+	env.SetOutput(&out)
+
+	program := parser.New(lexer.New(`let greet = fn(name) { puts("hello " + name, 1.5) }; greet("cok")`)).ParseProgram()
+	result := Eval(program, env)
+
+	testNullObject(t, result)
+	if out.String() != "hello cok\n1.5\n" {
+		t.Errorf("wrong output. got=%q", out.String())
+	}
+}
+
+func TestExecutionLimits(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		input    string
+		limits   object.Limits
+		expected string
+		kind     object.LimitKind
+	}{
+		{
+			"let f = fn(x) { f(x + 1) };\nf(0)",
+			object.Limits{},
+			"ERROR: 1:17: call depth limit exceeded: more than 10000 nested calls",
+			object.DEPTH_LIMIT,
+		},
+		{
+			"let f = fn(x) { if (x == 0) { 0 } else { f(x - 1) } };\nf(3)",
+			object.Limits{MaxDepth: 3},
+			"ERROR: 1:42: call depth limit exceeded: more than 3 nested calls",
+			object.DEPTH_LIMIT,
+		},
+		{
+			"let x = 1 + 2;\nx * (x + 4)",
+			object.Limits{MaxSteps: 6},
+			"ERROR: 2:1: step limit exceeded: more than 6 evaluation steps",
+			object.STEP_LIMIT,
+		},
+		{
+			"let f = fn() { 1 };\n  f()",
+			object.Limits{Context: canceled},
+			"ERROR: 2:3: execution canceled: context canceled",
+			object.CONTEXT_LIMIT,
+		},
+	}
+
+	for _, tt := range tests {
+		env := object.NewEnvironment()
+		*env.Budget() = object.Budget{Limits: tt.limits}
+
+		result := Eval(parser.New(lexer.New(tt.input)).ParseProgram(), env)
+		errObj, ok := result.(*object.Error)
+		if !ok {
+			t.Errorf("%q: expected error, got=%T (%+v)", tt.input, result, result)
+			continue
+		}
+		if errObj.Inspect() != tt.expected || errObj.Limit != tt.kind {
+			t.Errorf("%q: wrong error. expected=%q (%s), got=%q (%s)", tt.input, tt.expected, tt.kind, errObj.Inspect(), errObj.Limit)
+		}
+	}
+}
+
+func TestLimitsAllowProgramsWithinBudget(t *testing.T) {
+	env := object.NewEnvironment()
+	*env.Budget() = object.Budget{Limits: object.Limits{MaxSteps: 1000, MaxDepth: 4}}
+
+	// f(3) memanggil f hingga kedalaman tepat 4
+	input := "let f = fn(x) { if (x == 0) { 0 } else { f(x - 1) } }; f(3); f(3)"
+	testIntegerObject(t, Eval(parser.New(lexer.New(input)).ParseProgram(), env), 0)
+
+	if budget := env.Budget(); budget.Depth != 0 {
+		t.Errorf("depth should return to 0 after calls finish. got=%d", budget.Depth)
+	}
+
+	// kesalahan biasa tidak ditandai sebagai batas eksekusi
+	if errObj, ok := testEval("1 + true").(*object.Error); !ok || errObj.Limit != "" {
+		t.Errorf("ordinary errors should have an empty Limit. got=%+v", errObj)
+	}
+}
+
+func testEval(input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	env := object.NewEnvironment()
+
+	return Eval(program, env)
+}
+
+func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
+	result, ok := obj.(*object.Integer)
+	if !ok {
+		t.Errorf("object is not Integer. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%d, want=%d", result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func testFloatObject(t *testing.T, obj object.Object, expected float64) bool {
+	result, ok := obj.(*object.Float)
+	if !ok {
+		t.Errorf("object is not Float. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if math.Abs(result.Value-expected) > 1e-9 {
+		t.Errorf("object has wrong value. got=%g, want=%g", result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
+	result, ok := obj.(*object.Boolean)
+	if !ok {
+		t.Errorf("object is not Boolean. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%t, want=%t", result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func testNullObject(t *testing.T, obj object.Object) bool {
+	if obj != NULL {
+		t.Errorf("object is not NULL. got=%T (%+v)", obj, obj)
+		return false
+	}
+	return true
+}
+
+func testStringObject(t *testing.T, obj object.Object, expected string) bool {
+	str, ok := obj.(*object.String)
+	if !ok {
+		t.Errorf("object is not String. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if str.Value != expected {
+		t.Errorf("String has wrong value. got=%q, want=%q", str.Value, expected)
+		return false
+	}
+	return true
+}
+
+func testErrorObject(t *testing.T, obj object.Object, expected string) bool {
+	errObj, ok := obj.(*object.Error)
+	if !ok {
+		t.Errorf("object is not Error. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if errObj.Message != expected {
+		t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+		return false
+	}
+	return true
+}
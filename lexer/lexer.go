@@ -1,21 +1,62 @@
 package lexer
 
 import (
+	"go-intepreter/diagnostic"
 	"go-intepreter/token"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type Lexer struct {
 	input        string
 	position     int
 	readPosition int
-	ch           byte
+	ch           rune // karakter (rune) saat ini; readPosition menunjuk ke byte setelahnya
+
+	// line dan column adalah posisi l.ch yang dimulai dari 1, digunakan untuk mengisi token.Position.
+	// column dihitung dalam rune, bukan byte.
+	file   string
+	line   int
+	column int
+
+	// comments menentukan apakah komentar dikembalikan sebagai token COMMENT atau dilewati.
+	comments bool
+
+	// errors berisi kesalahan leksikal, misalnya string yang tidak ditutup.
+	// Lexer tetap menghasilkan token agar parser dapat melanjutkan.
+	errors []diagnostic.Diagnostic
 }
 
 // postion & readPosition
 // Keduanya akan digunakan untuk mengakses karakter dalam input dengan menggunakannya sebagai indeks
 
-func New(input string) *Lexer {
-	l := &Lexer{input: input}
+// Option mengubah konfigurasi Lexer ketika dibuat dengan New.
+type Option func(*Lexer)
+
+// WithFilename mengisi field File pada setiap token.Position yang dihasilkan lexer,
+// sehingga pesan kesalahan dapat menyebutkan file:line:column.
+func WithFilename(name string) Option {
+	return func(l *Lexer) {
+		l.file = name
+	}
+}
+
+// WithComments membuat lexer mengembalikan komentar sebagai token COMMENT alih-alih
+// melewatinya, misalnya untuk formatter atau alat dokumentasi. Parser tidak mengenal
+// token COMMENT, jadi jangan gunakan opsi ini untuk lexer yang diberikan ke parser.
+func WithComments() Option {
+	return func(l *Lexer) {
+		l.comments = true
+	}
+}
+
+func New(input string, opts ...Option) *Lexer {
+	l := &Lexer{input: input, line: 1}
+	for _, opt := range opts {
+		opt(l)
+	}
 	l.readChar()
 	return l
 }
@@ -23,27 +64,77 @@ func New(input string) *Lexer {
 // Tujuan dari readChar adalah untuk memberi kita karakter berikutnya dan memajukan posisi kita dalam input string.
 // Hal pertama yang dilakukannya adalah memeriksa apakah kita telah mencapai akhir input.
 // Jika sudah maka ia akan mengatur l.ch ke 0, yang merupakan kode ASCII untuk karakter "NUL" dan menandakan "kita belum membaca apapun" atau "akhir file" untuk kita.
-// Tetapi jika kita belum mencapai akhir dari input, maka ia akan mengeset l.ch ke karakter berikutnya dengan mengakses l.input[l.readPosition].
-// Setelah itu l.position diperbarui ke l.readPosition yang baru saja digunakan dan l.readPosition bertambah satu.
+// Tetapi jika kita belum mencapai akhir dari input, maka ia akan mengeset l.ch ke rune berikutnya dengan mendekode UTF-8 mulai dari l.input[l.readPosition].
+// Setelah itu l.position diperbarui ke l.readPosition yang baru saja digunakan dan l.readPosition bertambah sebanyak byte rune tersebut.
+// Byte yang bukan UTF-8 valid dicatat sebagai kesalahan dan dibaca sebagai utf8.RuneError (U+FFFD).
 // Dengan begitu, l.readPosition selalu menunjuk ke posisi berikutnya di mana kita akan untuk membaca dari berikutnya dan l.position selalu menunjuk ke posisi di mana kita terakhir kali membaca
+// Sebelum maju, jika karakter sebelumnya adalah baris baru maka line bertambah dan column kembali ke awal.
+// Setelah mencapai akhir input, pemanggilan berikutnya tidak memajukan posisi lagi sehingga token EOF selalu
+// menunjuk tepat setelah karakter terakhir.
 func (l *Lexer) readChar() {
+	if l.readPosition > len(l.input) {
+		return
+	}
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+
+	size := 1
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
 	} else {
-		l.ch = l.input[l.readPosition]
+		l.ch, size = utf8.DecodeRuneInString(l.input[l.readPosition:])
 	}
 	l.position = l.readPosition
 
-	l.readPosition += 1
+	l.readPosition += size
+	l.column++
+
+	if l.ch == utf8.RuneError && size == 1 {
+		l.addError(diagnostic.InvalidUTF8, l.pos(), l.nextPos(), "save the file as UTF-8", "invalid UTF-8 byte 0x%02x", l.input[l.position])
+	}
+}
+
+// pos mengembalikan posisi karakter saat ini (l.ch).
+func (l *Lexer) pos() token.Position {
+	return token.Position{File: l.file, Line: l.line, Column: l.column, Offset: l.position}
+}
+
+// nextPos mengembalikan posisi tepat setelah karakter saat ini, tanpa memajukan lexer.
+func (l *Lexer) nextPos() token.Position {
+	return token.Position{File: l.file, Line: l.line, Column: l.column + 1, Offset: l.readPosition}
 }
 
 // Yang perlu dilakukan oleh lexer kita adalah mengenali apakah karakter saat ini adalah huruf,
 // dan jika ya, ia perlu membaca sisa pengenal/kata kunci hingga menemukan karakter yang bukan huruf.
 // Setelah membaca pengenal/kata kunci tersebut, kita perlu untuk mengetahui apakah itu adalah pengenal atau kata kunci, sehingga kita dapat menggunakan token.TokenType yang benar.
 // Langkah Langkah pertama adalah memperluas pernyataan switch kita:
+//
+// Setiap token diberi Pos (posisi karakter pertama) dan End (posisi tepat setelah karakter terakhir).
+// Komentar dilewati seperti spasi, kecuali lexer dibuat dengan WithComments.
 func (l *Lexer) NextToken() token.Token {
+	for {
+		l.skipWhitespace()
+		start := l.pos()
+
+		if l.ch == '/' && (l.peekChar() == '/' || l.peekChar() == '*') {
+			literal := l.readComment()
+			if !l.comments {
+				continue
+			}
+			return token.Token{Type: token.COMMENT, Literal: literal, Pos: start, End: l.pos()}
+		}
+
+		tok := l.nextToken()
+		tok.Pos = start
+		tok.End = l.pos()
+		return tok
+	}
+}
+
+func (l *Lexer) nextToken() token.Token {
 	var tok token.Token
-	l.skipWhitespace()
 	switch l.ch {
 	case '=':
 		// jika setelah token ASSIGN ada  karakter '='
@@ -79,6 +170,8 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.SEMICOLON, l.ch)
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
+	case ':':
+		tok = newToken(token.COLON, l.ch)
 	case '(':
 		tok = newToken(token.LPAREN, l.ch)
 	case ')':
@@ -87,6 +180,13 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
 		tok = newToken(token.RBRACE, l.ch)
+	case '[':
+		tok = newToken(token.LBRACKET, l.ch)
+	case ']':
+		tok = newToken(token.RBRACKET, l.ch)
+	case '"':
+		tok.Type = token.STRING
+		tok.Literal = l.readString()
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
@@ -96,8 +196,7 @@ func (l *Lexer) NextToken() token.Token {
 			tok.Type = token.LookupIdent(tok.Literal) //lexing pengenal dan kata kunci
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Type = token.INT
-			tok.Literal = l.readNumber()
+			tok.Type, tok.Literal = l.readNumber()
 			return tok
 		} else {
 			tok = newToken(token.ILEGAL, l.ch) //menangani karakter saat ini dan mendeklarasikan menyatakannya sebagai token.ILLEGAL.
@@ -108,10 +207,11 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
-// membaca sebuah identifier dan memajukan posisi lexer kita sampai bertemu dengan karakter non-huruf
+// membaca sebuah identifier dan memajukan posisi lexer kita sampai bertemu dengan karakter
+// yang bukan huruf, angka, atau _
 func (l *Lexer) readIdentifier() string {
 	position := l.position
-	for isLetter(l.ch) {
+	for isLetter(l.ch) || unicode.IsDigit(l.ch) {
 		l.readChar()
 	}
 
@@ -124,11 +224,16 @@ func (l *Lexer) readIdentifier() string {
 // kita akan memperlakukan _ sebagai huruf dan mengizinkannya dalam pengenal dan kata kunci. Ini berarti kita dapat menggunakan variabel
 // nama variabel seperti foo_bar. Bahasa pemrograman lain bahkan mengizinkan ! dan ? dalam pengenal. Jika Anda
 // ingin mengizinkannya juga, ini adalah tempat untuk menyelipkannya.
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+//
+// Aturan pengenal: karakter pertama adalah huruf Unicode (kategori L, misalnya a, Z, ä, é, ß, π)
+// atau _, dan karakter berikutnya boleh berupa huruf, _, atau angka desimal Unicode (kategori Nd).
+// Jadi nilai_ä, café dan x2 adalah pengenal, sedangkan 2x adalah angka 2 diikuti pengenal x.
+// Literal angka tetap hanya menggunakan angka Latin 0-9 (lihat isDigit).
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_'
 }
 
-func newToken(tokenType token.TokenType, ch byte) token.Token {
+func newToken(tokenType token.TokenType, ch rune) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
 
@@ -139,26 +244,235 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
-// currently only supports integers, for other data types such as float, double, for now it is not supported.
-func (l *Lexer) readNumber() string {
+// readComment membaca komentar baris (// sampai akhir baris) atau komentar blok (/* ... */)
+// dan mengembalikan teksnya termasuk penandanya. Komentar blok boleh bersarang, sehingga
+// /* a /* b */ c */ adalah satu komentar. Komentar blok yang tidak ditutup dicatat sebagai kesalahan.
+// Setelah readComment, l.ch menunjuk ke karakter pertama setelah komentar.
+func (l *Lexer) readComment() string {
+	start := l.pos()
 	position := l.position
-	for isDigit(l.ch) {
+
+	if l.peekChar() == '/' {
+		for l.position < len(l.input) && l.ch != '\n' {
+			l.readChar()
+		}
+		return l.input[position:l.position]
+	}
+
+	l.readChar()
+	l.readChar()
+	depth := 1
+	for depth > 0 {
+		switch {
+		case l.position >= len(l.input):
+			l.addError(diagnostic.UnterminatedComment, start, l.pos(), "add a closing `*/`", "unterminated block comment")
+			return l.input[position:]
+		case l.ch == '/' && l.peekChar() == '*':
+			depth++
+			l.readChar()
+			l.readChar()
+		case l.ch == '*' && l.peekChar() == '/':
+			depth--
+			l.readChar()
+			l.readChar()
+		default:
+			l.readChar()
+		}
+	}
+	return l.input[position:l.position]
+}
+
+// readNumber membaca literal angka dan mengembalikan token.INT atau token.FLOAT.
+//
+//	42  1_000_000  0xFF  0o755  0b1010_0101   (INT)
+//	3.14  1e-9  2.5E+3  1_000.000_1           (FLOAT)
+//
+// Float harus diawali angka: .5 tidak didukung (tulis 0.5), dan 1. dibaca sebagai INT 1 diikuti ".".
+// Eksponen hanya dibaca jika e/E diikuti angka (atau tanda + / - lalu angka).
+// Tanda _ boleh dipakai sebagai pemisah; letaknya (harus di antara angka) diperiksa oleh parser.
+func (l *Lexer) readNumber() (token.TokenType, string) {
+	position := l.position
+
+	if l.ch == '0' {
+		switch l.peekChar() {
+		case 'x', 'X':
+			return token.INT, l.readPrefixedNumber(position, isHexDigit)
+		case 'o', 'O':
+			return token.INT, l.readPrefixedNumber(position, isOctalDigit)
+		case 'b', 'B':
+			return token.INT, l.readPrefixedNumber(position, isBinaryDigit)
+		}
+	}
+
+	var tokenType token.TokenType = token.INT
+	l.readDigits(isDigit)
+
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		tokenType = token.FLOAT
+		l.readChar()
+		l.readDigits(isDigit)
+	}
+
+	if (l.ch == 'e' || l.ch == 'E') && l.exponentFollows() {
+		tokenType = token.FLOAT
 		l.readChar()
+		if l.ch == '+' || l.ch == '-' {
+			l.readChar()
+		}
+		l.readDigits(isDigit)
 	}
+
+	return tokenType, l.input[position:l.position]
+}
+
+// readPrefixedNumber membaca literal dengan awalan basis 0x, 0o atau 0b.
+func (l *Lexer) readPrefixedNumber(position int, valid func(rune) bool) string {
+	l.readChar()
+	l.readChar()
+	l.readDigits(valid)
 	return l.input[position:l.position]
 }
 
+func (l *Lexer) readDigits(valid func(rune) bool) {
+	for valid(l.ch) || l.ch == '_' {
+		l.readChar()
+	}
+}
+
+// exponentFollows melaporkan apakah e/E saat ini diikuti angka, misalnya 1e9 atau 1e-9.
+func (l *Lexer) exponentFollows() bool {
+	next := l.peekChar()
+	if isDigit(next) {
+		return true
+	}
+	return (next == '+' || next == '-') && l.readPosition+1 < len(l.input) && isDigit(rune(l.input[l.readPosition+1]))
+}
+
 // Fungsi ini hanya mengembalikan apakah byte yang dimasukkan adalah sebuah Angka Latin antara 0 dan 9.
-func isDigit(ch byte) bool {
+func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
 
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isOctalDigit(ch rune) bool {
+	return '0' <= ch && ch <= '7'
+}
+
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
+}
+
 // Ketika lexer menemukan == pada input, ia akan membuat dua token.ASSIGN, bukan satu token token.EQ token. Solusinya adalah dengan menggunakan metode peekChar() yang baru.
 // Di cabang-cabang dari pernyataan pernyataan switch untuk '=' dan '!' kita "mengintip" ke depan. Jika token berikutnya juga merupakan =, kita membuat token.EQ atau token.NOT_EQ:
-func (l *Lexer) peekChar() byte {
+func (l *Lexer) peekChar() rune {
 	if l.readPosition >= len(l.input) {
 		return 0
 	} else {
-		return l.input[l.readPosition]
+		r, _ := utf8.DecodeRuneInString(l.input[l.readPosition:])
+		return r
+	}
+}
+
+// Errors mengembalikan kesalahan leksikal yang ditemukan sejauh ini.
+func (l *Lexer) Errors() []diagnostic.Diagnostic {
+	return l.errors
+}
+
+func (l *Lexer) addError(code diagnostic.Code, start, end token.Position, hint string, format string, a ...interface{}) {
+	d := diagnostic.New(code, diagnostic.Span{Start: start, End: end}, format, a...)
+	d.Hint = hint
+	l.errors = append(l.errors, d)
+}
+
+// readString membaca literal string yang diapit tanda kutip ganda dan mengembalikan isinya
+// setelah escape sequence diterjemahkan. Escape yang didukung:
+//
+//	\n \t \r \" \\ dan \u{XXXX} (kode Unicode dalam heksadesimal, 1-6 digit)
+//
+// String boleh terdiri dari beberapa baris. Jika input berakhir sebelum tanda kutip penutup,
+// lexer mencatat kesalahan dan mengembalikan isi string yang sudah terbaca.
+// Setelah readString, l.ch menunjuk ke tanda kutip penutup (atau EOF).
+func (l *Lexer) readString() string {
+	start := l.pos()
+	var out strings.Builder
+
+	for {
+		l.readChar()
+
+		switch l.ch {
+		case '"':
+			return out.String()
+		case 0:
+			if l.position >= len(l.input) {
+				l.addError(diagnostic.UnterminatedString, start, l.pos(), "add a closing `\"`", "unterminated string literal")
+				return out.String()
+			}
+			out.WriteRune(l.ch)
+		case '\\':
+			l.readEscape(&out)
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
+}
+
+// readEscape menerjemahkan satu escape sequence. l.ch adalah \ saat dipanggil
+// dan karakter terakhir dari escape sequence setelahnya.
+func (l *Lexer) readEscape(out *strings.Builder) {
+	start := l.pos()
+	l.readChar()
+
+	switch l.ch {
+	case 'n':
+		out.WriteByte('\n')
+	case 't':
+		out.WriteByte('\t')
+	case 'r':
+		out.WriteByte('\r')
+	case '"':
+		out.WriteByte('"')
+	case '\\':
+		out.WriteByte('\\')
+	case 'u':
+		l.readUnicodeEscape(out, start)
+	default:
+		if l.ch == 0 && l.position >= len(l.input) {
+			// kesalahan string yang tidak ditutup akan dilaporkan oleh readString
+			return
+		}
+		l.addError(diagnostic.InvalidEscape, start, l.nextPos(), "supported escapes are \\n, \\t, \\r, \\\", \\\\ and \\u{...}", "unknown escape sequence \\%c", l.ch)
+		out.WriteRune(l.ch)
+	}
+}
+
+// readUnicodeEscape membaca bagian {XXXX} dari \u{XXXX}.
+func (l *Lexer) readUnicodeEscape(out *strings.Builder, start token.Position) {
+	if l.peekChar() != '{' {
+		l.addError(diagnostic.InvalidEscape, start, l.pos(), "write unicode escapes as \\u{1F600}", "expected { after \\u")
+		return
+	}
+	l.readChar()
+
+	digits := l.readPosition
+	for l.peekChar() != '}' && l.peekChar() != '"' && l.peekChar() != 0 {
+		l.readChar()
+	}
+	hex := l.input[digits:l.readPosition]
+
+	if l.peekChar() != '}' {
+		l.addError(diagnostic.InvalidEscape, start, l.pos(), "write unicode escapes as \\u{1F600}", "unterminated unicode escape \\u{%s", hex)
+		return
+	}
+	l.readChar()
+	end := l.nextPos()
+
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) > 6 || !utf8.ValidRune(rune(code)) {
+		l.addError(diagnostic.InvalidEscape, start, end, "", "invalid unicode code point \\u{%s}", hex)
+		return
 	}
+	out.WriteRune(rune(code))
 }
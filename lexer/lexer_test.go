@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"go-intepreter/diagnostic"
 	"go-intepreter/token"
 	"os"
 	"testing"
@@ -101,3 +102,347 @@ func TestNextToken(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 10;\n  x == 5;\n"
+
+	tests := []struct {
+		expectedType token.TokenType
+		line, column int
+		offset       int
+		endColumn    int
+	}{
+		{token.LET, 1, 1, 0, 4},
+		{token.IDENT, 1, 5, 4, 6},
+		{token.ASSIGN, 1, 7, 6, 8},
+		{token.INT, 1, 9, 8, 11},
+		{token.SEMICOLON, 1, 11, 10, 12},
+		{token.IDENT, 2, 3, 14, 4},
+		{token.EQ, 2, 5, 16, 7},
+		{token.INT, 2, 8, 19, 9},
+		{token.SEMICOLON, 2, 9, 20, 10},
+		{token.EOF, 3, 1, 22, 1},
+		{token.EOF, 3, 1, 22, 1},
+	}
+
+	l := New(input, WithFilename("main.cok"))
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("test[%d] tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Pos.Line != tt.line || tok.Pos.Column != tt.column || tok.Pos.Offset != tt.offset {
+			t.Errorf("test[%d] position wrong. expected=%d:%d (offset %d), got=%d:%d (offset %d)",
+				i, tt.line, tt.column, tt.offset, tok.Pos.Line, tok.Pos.Column, tok.Pos.Offset)
+		}
+
+		if tok.End.Column != tt.endColumn {
+			t.Errorf("test[%d] end column wrong. expected=%d, got=%d", i, tt.endColumn, tok.End.Column)
+		}
+
+		if tok.Pos.File != "main.cok" {
+			t.Errorf("test[%d] file wrong. expected=%q, got=%q", i, "main.cok", tok.Pos.File)
+		}
+	}
+}
+
+func TestStringTokens(t *testing.T) {
+	input := `"foobar" "foo bar" "a\"b" "multi
+line";`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.STRING, "foobar"},
+		{token.STRING, "foo bar"},
+		{token.STRING, `a"b`},
+		{token.STRING, "multi\nline"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("test[%d] tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("test[%d] literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected lexer errors: %v", l.Errors())
+	}
+}
+
+func TestUnterminatedString(t *testing.T) {
+	l := New(`let a = "abc`)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	if len(l.Errors()) != 1 {
+		t.Fatalf("expected 1 lexer error, got=%d", len(l.Errors()))
+	}
+
+	d := l.Errors()[0]
+	if d.Span.Start.Column != 9 || d.Span.End.Column != 13 {
+		t.Errorf("wrong span. got=%s-%s", d.Span.Start, d.Span.End)
+	}
+}
+
+func TestArrayAndHashTokens(t *testing.T) {
+	input := `let myArray = [1, 2];
+myArray[0];
+{"foo": "bar"}`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "myArray"},
+		{token.ASSIGN, "="},
+		{token.LBRACKET, "["},
+		{token.INT, "1"},
+		{token.COMMA, ","},
+		{token.INT, "2"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "myArray"},
+		{token.LBRACKET, "["},
+		{token.INT, "0"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.LBRACE, "{"},
+		{token.STRING, "foo"},
+		{token.COLON, ":"},
+		{token.STRING, "bar"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("test[%d] tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("test[%d] literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// header comment
+let a = 5; // trailing
+/* block
+   comment */ let b = a / 2;
+/* outer /* nested */ still comment */ a * b`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "a"},
+		{token.ASSIGN, "="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.LET, "let"},
+		{token.IDENT, "b"},
+		{token.ASSIGN, "="},
+		{token.IDENT, "a"},
+		{token.SLASH, "/"},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "a"},
+		{token.ASTERISK, "*"},
+		{token.IDENT, "b"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q %q, got=%q %q", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected lexer errors: %v", l.Errors())
+	}
+}
+
+func TestCommentTokens(t *testing.T) {
+	input := "// line\nx /* a /* b */ c */;"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedPos     string
+		expectedEnd     string
+	}{
+		{token.COMMENT, "// line", "1:1", "1:8"},
+		{token.IDENT, "x", "2:1", "2:2"},
+		{token.COMMENT, "/* a /* b */ c */", "2:3", "2:20"},
+		{token.SEMICOLON, ";", "2:20", "2:21"},
+		{token.EOF, "", "2:21", "2:21"},
+	}
+
+	l := New(input, WithComments())
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q %q, got=%q %q", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+		if tok.Pos.String() != tt.expectedPos || tok.End.String() != tt.expectedEnd {
+			t.Errorf("tests[%d] - wrong span. expected=%s-%s, got=%s-%s", i, tt.expectedPos, tt.expectedEnd, tok.Pos, tok.End)
+		}
+	}
+}
+
+func TestUnterminatedBlockComment(t *testing.T) {
+	l := New("let a = 1; /* outer /* inner */ never closed")
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	if len(l.Errors()) != 1 {
+		t.Fatalf("expected 1 lexer error, got=%d", len(l.Errors()))
+	}
+
+	d := l.Errors()[0]
+	if d.Code != diagnostic.UnterminatedComment {
+		t.Errorf("wrong code. expected=%s, got=%s", diagnostic.UnterminatedComment, d.Code)
+	}
+	if d.Span.Start.Column != 12 || d.Span.End.Column != 45 {
+		t.Errorf("wrong span. got=%s-%s", d.Span.Start, d.Span.End)
+	}
+}
+
+func TestUnicodeIdentifiersAndStrings(t *testing.T) {
+	input := "let nilai_ä = \"Selamat pagi, Bu Siti! ñ é 😀\";\ncafé + π2 + _x; 2x"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedPos     string
+		expectedEnd     string
+	}{
+		{token.LET, "let", "1:1", "1:4"},
+		{token.IDENT, "nilai_ä", "1:5", "1:12"},
+		{token.ASSIGN, "=", "1:13", "1:14"},
+		{token.STRING, "Selamat pagi, Bu Siti! ñ é 😀", "1:15", "1:45"},
+		{token.SEMICOLON, ";", "1:45", "1:46"},
+		{token.IDENT, "café", "2:1", "2:5"},
+		{token.PLUS, "+", "2:6", "2:7"},
+		{token.IDENT, "π2", "2:8", "2:10"},
+		{token.PLUS, "+", "2:11", "2:12"},
+		{token.IDENT, "_x", "2:13", "2:15"},
+		{token.SEMICOLON, ";", "2:15", "2:16"},
+		{token.INT, "2", "2:17", "2:18"},
+		{token.IDENT, "x", "2:18", "2:19"},
+		{token.EOF, "", "2:19", "2:19"},
+	}
+
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q %q, got=%q %q", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+		if tok.Pos.String() != tt.expectedPos || tok.End.String() != tt.expectedEnd {
+			t.Errorf("tests[%d] - wrong span. expected=%s-%s, got=%s-%s", i, tt.expectedPos, tt.expectedEnd, tok.Pos, tok.End)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected lexer errors: %v", l.Errors())
+	}
+
+	// Offset tetap dalam byte: "café" berakhir di byte ke-5
+	l = New("café;")
+	if tok := l.NextToken(); tok.End.Offset != 5 {
+		t.Errorf("wrong end offset. expected=5, got=%d", tok.End.Offset)
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	l := New("let a = \"x\xffy\"; \xfe")
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "a"},
+		{token.ASSIGN, "="},
+		{token.STRING, "x�y"},
+		{token.SEMICOLON, ";"},
+		{token.ILEGAL, "�"},
+		{token.EOF, ""},
+	}
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q %q, got=%q %q", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+
+	errors := l.Errors()
+	if len(errors) != 2 {
+		t.Fatalf("expected 2 lexer errors, got=%d", len(errors))
+	}
+	if errors[0].Code != diagnostic.InvalidUTF8 || errors[0].String() != "1:11: invalid UTF-8 byte 0xff" {
+		t.Errorf("wrong first error. got=%s %s", errors[0].Code, errors[0])
+	}
+	if errors[1].String() != "1:16: invalid UTF-8 byte 0xfe" {
+		t.Errorf("wrong second error. got=%s", errors[1])
+	}
+}
+
+func TestNumberTokens(t *testing.T) {
+	input := "42 1_000 3.14 1e-9 2.5E+3 0xFF 0o17 0b1010 .5 1. 1e x2 1else"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "42"},
+		{token.INT, "1_000"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "1e-9"},
+		{token.FLOAT, "2.5E+3"},
+		{token.INT, "0xFF"},
+		{token.INT, "0o17"},
+		{token.INT, "0b1010"},
+		// .5 tidak didukung: titik bukan awal literal float
+		{token.ILEGAL, "."},
+		{token.INT, "5"},
+		{token.INT, "1"},
+		{token.ILEGAL, "."},
+		// e tanpa angka bukan eksponen
+		{token.INT, "1"},
+		{token.IDENT, "e"},
+		{token.IDENT, "x2"},
+		{token.INT, "1"},
+		{token.ELSE, "else"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q %q, got=%q %q", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"go-intepreter/repl"
+	"go-intepreter/runner"
 	"os"
 	"os/user"
+	"path/filepath"
 )
 
 const LOGO = `
@@ -16,7 +18,35 @@ _________  ________   ____  __. .____       _____    _______    ________
         \/         \/        \/         \/       \/         \/        \/ 
 `
 
+const usage = `usage:
+  cok                         start the REPL
+  cok run <file.cok> [args]   run a program ("-" reads it from stdin)
+  cok < file.cok              run a program piped through stdin`
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "run":
+			if len(os.Args) < 3 {
+				fmt.Fprintln(os.Stderr, usage)
+				os.Exit(runner.ExitUsage)
+			}
+			os.Exit(runner.RunFile(os.Args[2], os.Args[3:], os.Stdin, os.Stdout, os.Stderr))
+		case "help", "-h", "--help":
+			fmt.Println(usage)
+			return
+		default:
+			fmt.Fprintf(os.Stderr, "cok: unknown command %q\n%s\n", os.Args[1], usage)
+			os.Exit(runner.ExitUsage)
+		}
+	}
+
+	// jika stdin bukan terminal (misalnya `cok < file.cok` atau `cat file.cok | cok`),
+	// program dibaca dari stdin dan dijalankan tanpa REPL
+	if !isTerminal(os.Stdin) {
+		os.Exit(runner.RunFile(runner.StdinName, nil, os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	user, err := user.Current()
 	if err != nil {
 		panic(err)
@@ -25,5 +55,20 @@ func main() {
 
 	fmt.Printf("hello %s! welcome to  REPL The COKLang v1.0.0\n", user.Username)
 	fmt.Printf("Feel free to type commands\n")
-	repl.Start(os.Stdin, os.Stdout)
+
+	// riwayat REPL disimpan di ~/.cok_history; tanpa direktori home riwayat hanya ada di memori
+	opts := repl.Options{}
+	if home, err := os.UserHomeDir(); err == nil {
+		opts.HistoryFile = filepath.Join(home, repl.HISTORY_FILE)
+	}
+	repl.StartWithOptions(os.Stdin, os.Stdout, opts)
+}
+
+// isTerminal melaporkan apakah f adalah perangkat karakter (terminal), bukan pipe atau file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
@@ -0,0 +1,111 @@
+package object
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+)
+
+// Environment menyimpan pengikatan nama ke nilai yang dibuat oleh pernyataan let.
+// Ini hanyalah sebuah hash map yang mengasosiasikan string dengan Object.
+//
+// Setiap pemanggilan fungsi mendapat Environment baru yang diapit oleh environment tempat
+// fungsi itu didefinisikan (outer). Get mencari nama di environment ini terlebih dahulu,
+// lalu di outer, dan seterusnya ke luar. Set selalu mengikat nama di environment ini,
+// sehingga let di dalam fungsi membayangi (shadowing) nama yang sama di luar tanpa mengubahnya.
+type Environment struct {
+	store  map[string]Object
+	outer  *Environment
+	out    io.Writer
+	budget *Budget
+}
+
+func NewEnvironment() *Environment {
+	s := make(map[string]Object)
+	return &Environment{store: s, outer: nil, budget: &Budget{}}
+}
+
+// NewEnclosedEnvironment membuat environment baru untuk lingkup di dalam outer,
+// misalnya badan fungsi yang sedang dipanggil. Environment baru memakai Budget yang sama
+// dengan outer.
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+	env.budget = outer.budget
+	return env
+}
+
+func (e *Environment) Get(name string) (Object, bool) {
+	obj, ok := e.store[name]
+	if !ok && e.outer != nil {
+		obj, ok = e.outer.Get(name)
+	}
+	return obj, ok
+}
+
+func (e *Environment) Set(name string, val Object) Object {
+	e.store[name] = val
+	return val
+}
+
+// Names mengembalikan semua nama yang terlihat dari environment ini, termasuk nama
+// dari environment luar, diurutkan secara alfabetis.
+func (e *Environment) Names() []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for env := e; env != nil; env = env.outer {
+		for name := range env.store {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetOutput menentukan tujuan keluaran program (misalnya builtin puts) untuk environment ini
+// dan semua environment yang diapitnya.
+func (e *Environment) SetOutput(w io.Writer) {
+	e.out = w
+}
+
+// Output mengembalikan tujuan keluaran yang diatur dengan SetOutput pada environment ini atau
+// environment luarnya. Jika tidak ada yang mengatur, keluaran ditulis ke os.Stdout.
+func (e *Environment) Output() io.Writer {
+	for env := e; env != nil; env = env.outer {
+		if env.out != nil {
+			return env.out
+		}
+	}
+	return os.Stdout
+}
+
+// DEFAULT_MAX_DEPTH adalah kedalaman pemanggilan fungsi maksimum jika Limits.MaxDepth nol.
+// Batas ini mencegah rekursi tanpa akhir menghabiskan stack Go dan menghentikan proses.
+const DEFAULT_MAX_DEPTH = 10000
+
+// Limits membatasi eksekusi program. MaxSteps nol berarti tanpa batas langkah, MaxDepth nol
+// berarti DEFAULT_MAX_DEPTH, dan Context nil berarti eksekusi tidak dapat dibatalkan.
+type Limits struct {
+	MaxSteps int             // jumlah maksimum node yang dievaluasi
+	MaxDepth int             // kedalaman maksimum pemanggilan fungsi CokLang
+	Context  context.Context // diperiksa setiap kali sebuah fungsi dipanggil
+}
+
+// Budget mencatat pemakaian Limits selama eksekusi. Satu Budget dipakai bersama oleh environment
+// global dan semua environment yang diapitnya, sehingga Steps dan Depth dihitung untuk seluruh
+// program, termasuk di dalam closure.
+type Budget struct {
+	Limits
+	Steps int
+	Depth int
+}
+
+// Budget mengembalikan Budget milik environment ini. Untuk mengganti batas, timpa nilainya,
+// misalnya *env.Budget() = Budget{Limits: limits}.
+func (e *Environment) Budget() *Budget {
+	return e.budget
+}
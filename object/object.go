@@ -0,0 +1,309 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"go-intepreter/ast"
+	"go-intepreter/token"
+	"hash/fnv"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Object adalah representasi internal dari setiap nilai yang dihasilkan ketika mengevaluasi program CokLang.
+// Setiap nilai (integer, boolean, null, dll) dibungkus dalam struct yang memenuhi antarmuka Object,
+// sehingga evaluator dapat memperlakukan semua nilai dengan cara yang sama.
+type ObjectType string
+
+const (
+	INTEGER_OBJ      = "INTEGER"
+	FLOAT_OBJ        = "FLOAT"
+	BOOLEAN_OBJ      = "BOOLEAN"
+	NULL_OBJ         = "NULL"
+	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	ERROR_OBJ        = "ERROR"
+	STRING_OBJ       = "STRING"
+	ARRAY_OBJ        = "ARRAY"
+	HASH_OBJ         = "HASH"
+	FUNCTION_OBJ     = "FUNCTION"
+	BUILTIN_OBJ      = "BUILTIN"
+)
+
+type Object interface {
+	Type() ObjectType
+	Inspect() string // mencetak nilai objek, digunakan oleh REPL dan untuk debugging
+}
+
+// Integer membungkus nilai int64 dari ast.IntegerLiteral.
+type Integer struct {
+	Value int64
+}
+
+func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
+func (i *Integer) Inspect() string  { return fmt.Sprintf("%d", i.Value) }
+
+// BigInteger adalah integer yang tidak muat di int64, misalnya literal 99999999999999999999
+// atau hasil perkalian yang overflow. Bagi program CokLang tipenya tetap INTEGER; evaluator
+// berpindah ke math/big secara otomatis ketika hasil int64 akan overflow, dan kembali ke
+// Integer ketika hasilnya muat lagi (lihat NewInteger).
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (bi *BigInteger) Type() ObjectType { return INTEGER_OBJ }
+func (bi *BigInteger) Inspect() string  { return bi.Value.String() }
+
+// NewInteger mengembalikan *Integer jika v muat di int64, dan *BigInteger jika tidak,
+// sehingga setiap nilai integer hanya memiliki satu representasi.
+func NewInteger(v *big.Int) Object {
+	if v.IsInt64() {
+		return &Integer{Value: v.Int64()}
+	}
+	return &BigInteger{Value: v}
+}
+
+// Float membungkus nilai float64 dari ast.FloatLiteral atau hasil aritmetika campuran integer/float.
+// Inspect selalu menampilkan titik desimal atau eksponen (3.0, bukan 3) agar tidak tertukar dengan Integer.
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() ObjectType { return FLOAT_OBJ }
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eIN") {
+		s += ".0"
+	}
+	return s
+}
+
+type Boolean struct {
+	Value bool
+}
+
+func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
+func (b *Boolean) Inspect() string  { return fmt.Sprintf("%t", b.Value) }
+
+// Null merepresentasikan ketiadaan nilai. Null tidak membungkus nilai apa pun.
+type Null struct{}
+
+func (n *Null) Type() ObjectType { return NULL_OBJ }
+func (n *Null) Inspect() string  { return "null" }
+
+// ReturnValue membungkus nilai yang dikembalikan oleh pernyataan return,
+// sehingga evaluator tahu bahwa ia harus berhenti mengevaluasi pernyataan berikutnya.
+type ReturnValue struct {
+	Value Object
+}
+
+func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
+func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
+
+// Error adalah kesalahan runtime, misalnya operator yang tidak dikenal atau tipe yang tidak cocok.
+// Sama seperti ReturnValue, sebuah Error menghentikan evaluasi program.
+//
+// Pos diisi dengan posisi node yang menghasilkan kesalahan (misalnya operator infiks atau pengenal),
+// atau posisi pemanggilan (call site) untuk kesalahan dari pemanggilan fungsi, misalnya jumlah
+// argumen yang salah pada builtin. Pos kosong jika kesalahan berasal dari pemanggilan dari Go.
+//
+// Limit diisi jika evaluasi dihentikan karena batas eksekusi (lihat Limits) terlampaui,
+// sehingga pemanggil dapat membedakannya dari kesalahan program biasa.
+type Error struct {
+	Message string
+	Pos     token.Position
+	Limit   LimitKind
+}
+
+// LimitKind menyebutkan batas eksekusi yang terlampaui.
+type LimitKind string
+
+const (
+	STEP_LIMIT    LimitKind = "steps"
+	DEPTH_LIMIT   LimitKind = "depth"
+	CONTEXT_LIMIT LimitKind = "context"
+)
+
+func (e *Error) Type() ObjectType { return ERROR_OBJ }
+func (e *Error) Inspect() string {
+	if e.Pos.IsValid() {
+		return "ERROR: " + e.Pos.String() + ": " + e.Message
+	}
+	return "ERROR: " + e.Message
+}
+
+// Function adalah nilai dari ekspresi fn. Selain parameter dan badan fungsi, Function menyimpan
+// Env, yaitu environment tempat fungsi tersebut dibuat. Ketika fungsi dipanggil, badan fungsi
+// dievaluasi di environment baru yang diapit (enclosed) oleh Env, sehingga fungsi dapat mengakses
+// nama-nama dari lingkup tempat ia didefinisikan, bahkan setelah lingkup itu selesai (closure).
+type Function struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatement
+	Env        *Environment
+}
+
+func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
+func (f *Function) Inspect() string {
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range f.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString("fn(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") ")
+	out.WriteString(f.Body.String())
+
+	return out.String()
+}
+
+// BuiltinFunction adalah implementasi Go dari fungsi bawaan. env adalah environment pemanggil,
+// misalnya untuk menulis ke env.Output(). Kesalahan dikembalikan sebagai *Error.
+type BuiltinFunction func(env *Environment, args ...Object) Object
+
+// Builtin adalah fungsi bawaan seperti len atau puts yang diimplementasikan di Go.
+type Builtin struct {
+	Name string
+	Fn   BuiltinFunction
+}
+
+func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
+func (b *Builtin) Inspect() string  { return "builtin " + b.Name }
+
+type String struct {
+	Value string
+}
+
+func (s *String) Type() ObjectType { return STRING_OBJ }
+func (s *String) Inspect() string  { return s.Value }
+
+type Array struct {
+	Elements []Object
+}
+
+func (ao *Array) Type() ObjectType { return ARRAY_OBJ }
+func (ao *Array) Inspect() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, e := range ao.Elements {
+		elements = append(elements, e.Inspect())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// HashKey adalah kunci yang digunakan untuk menyimpan pasangan di dalam Hash.
+// Dua objek dengan tipe dan nilai yang sama selalu menghasilkan HashKey yang sama,
+// meskipun keduanya adalah pointer yang berbeda. Type ikut disimpan agar 1 dan true
+// tidak dianggap sebagai kunci yang sama.
+type HashKey struct {
+	Type  ObjectType
+	Value uint64
+}
+
+// Hashable diimplementasikan oleh objek yang boleh digunakan sebagai kunci hash:
+// Integer, Boolean dan String.
+type Hashable interface {
+	HashKey() HashKey
+}
+
+func (b *Boolean) HashKey() HashKey {
+	var value uint64
+
+	if b.Value {
+		value = 1
+	} else {
+		value = 0
+	}
+
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+// bigIntegerKey memisahkan kunci BigInteger dari kunci Integer. Karena NewInteger selalu
+// memilih Integer untuk nilai yang muat di int64, keduanya tidak pernah bernilai sama.
+const bigIntegerKey ObjectType = "BIG_INTEGER"
+
+func (bi *BigInteger) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write(bi.Value.Bytes())
+	if bi.Value.Sign() < 0 {
+		h.Write([]byte{'-'})
+	}
+	return HashKey{Type: bigIntegerKey, Value: h.Sum64()}
+}
+
+// HashKey untuk String dihitung dengan FNV-1a 64-bit, sehingga hasilnya stabil antar proses.
+func (s *String) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(s.Value))
+
+	return HashKey{Type: s.Type(), Value: h.Sum64()}
+}
+
+// HashPair menyimpan kunci asli di samping nilainya, agar Inspect dan iterasi
+// dapat menampilkan kunci tersebut, bukan hanya HashKey-nya.
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// Hash menyimpan pasangan kunci-nilai. Keys mencatat urutan penyisipan, sehingga
+// Inspect dan iterasi selalu menghasilkan urutan yang sama dengan literal di kode sumber.
+type Hash struct {
+	Pairs map[HashKey]HashPair
+	Keys  []HashKey
+}
+
+func NewHash() *Hash {
+	return &Hash{Pairs: make(map[HashKey]HashPair)}
+}
+
+// Set menyimpan pasangan. Jika kunci sudah ada, nilainya diganti tetapi urutannya tetap.
+func (h *Hash) Set(key Hashable, value Object) {
+	hashed := key.HashKey()
+	if _, ok := h.Pairs[hashed]; !ok {
+		h.Keys = append(h.Keys, hashed)
+	}
+	h.Pairs[hashed] = HashPair{Key: key.(Object), Value: value}
+}
+
+func (h *Hash) Get(key Hashable) (Object, bool) {
+	pair, ok := h.Pairs[key.HashKey()]
+	return pair.Value, ok
+}
+
+// Ordered mengembalikan semua pasangan sesuai urutan penyisipan.
+func (h *Hash) Ordered() []HashPair {
+	pairs := make([]HashPair, 0, len(h.Keys))
+	for _, k := range h.Keys {
+		pairs = append(pairs, h.Pairs[k])
+	}
+	return pairs
+}
+
+func (h *Hash) Type() ObjectType { return HASH_OBJ }
+func (h *Hash) Inspect() string {
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for _, pair := range h.Ordered() {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", pair.Key.Inspect(), pair.Value.Inspect()))
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
@@ -0,0 +1,114 @@
+package object
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestStringHashKey(t *testing.T) {
+	hello1 := &String{Value: "Hello World"}
+	hello2 := &String{Value: "Hello World"}
+	diff1 := &String{Value: "My name is johnny"}
+	diff2 := &String{Value: "My name is johnny"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if diff1.HashKey() != diff2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if hello1.HashKey() == diff1.HashKey() {
+		t.Errorf("strings with different content have same hash keys")
+	}
+}
+
+func TestHashKeyIncludesType(t *testing.T) {
+	one := &Integer{Value: 1}
+	yes := &Boolean{Value: true}
+
+	if one.HashKey() == yes.HashKey() {
+		t.Errorf("1 and true must not share a hash key")
+	}
+}
+
+func TestHashKeepsInsertionOrder(t *testing.T) {
+	h := NewHash()
+	h.Set(&String{Value: "b"}, &Integer{Value: 1})
+	h.Set(&String{Value: "a"}, &Integer{Value: 2})
+	h.Set(&Integer{Value: 3}, &Boolean{Value: true})
+	h.Set(&String{Value: "b"}, &Integer{Value: 4})
+
+	expected := "{b: 4, a: 2, 3: true}"
+	if h.Inspect() != expected {
+		t.Errorf("wrong Inspect. expected=%q, got=%q", expected, h.Inspect())
+	}
+
+	value, ok := h.Get(&String{Value: "a"})
+	if !ok || value.Inspect() != "2" {
+		t.Errorf("wrong value for key a. got=%v (%t)", value, ok)
+	}
+}
+
+func TestNewIntegerNormalizes(t *testing.T) {
+	small := NewInteger(big.NewInt(42))
+	if i, ok := small.(*Integer); !ok || i.Value != 42 {
+		t.Errorf("42 should be an *Integer. got=%T (%+v)", small, small)
+	}
+
+	huge, _ := new(big.Int).SetString("99999999999999999999", 10)
+	large := NewInteger(huge)
+	if _, ok := large.(*BigInteger); !ok {
+		t.Errorf("99999999999999999999 should be a *BigInteger. got=%T", large)
+	}
+
+	other, _ := new(big.Int).SetString("99999999999999999999", 10)
+	if large.(*BigInteger).HashKey() != (&BigInteger{Value: other}).HashKey() {
+		t.Errorf("equal big integers have different hash keys")
+	}
+	if large.(*BigInteger).HashKey() == (&BigInteger{Value: new(big.Int).Neg(other)}).HashKey() {
+		t.Errorf("x and -x must not share a hash key")
+	}
+}
+
+func TestEnclosedEnvironment(t *testing.T) {
+	outer := NewEnvironment()
+	outer.Set("x", &Integer{Value: 1})
+	outer.Set("y", &Integer{Value: 2})
+
+	inner := NewEnclosedEnvironment(outer)
+	inner.Set("x", &Integer{Value: 10})
+	inner.Set("z", &Integer{Value: 3})
+
+	tests := []struct {
+		env      *Environment
+		name     string
+		expected int64
+	}{
+		{inner, "x", 10},
+		{inner, "y", 2},
+		{inner, "z", 3},
+		{outer, "x", 1},
+	}
+
+	for _, tt := range tests {
+		obj, ok := tt.env.Get(tt.name)
+		if !ok {
+			t.Errorf("%s not found", tt.name)
+			continue
+		}
+		if obj.(*Integer).Value != tt.expected {
+			t.Errorf("%s has wrong value. expected=%d, got=%d", tt.name, tt.expected, obj.(*Integer).Value)
+		}
+	}
+
+	if _, ok := outer.Get("z"); ok {
+		t.Errorf("z must not leak into the outer environment")
+	}
+
+	if names := strings.Join(inner.Names(), ","); names != "x,y,z" {
+		t.Errorf("wrong names. expected=x,y,z, got=%s", names)
+	}
+}
@@ -3,9 +3,13 @@ package parser
 import (
 	"fmt"
 	"go-intepreter/ast"
+	"go-intepreter/diagnostic"
 	"go-intepreter/lexer"
 	"go-intepreter/token"
+	"math/big"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 // Parser adalah suatu program atau fungsi dalam suatu sistem komputer yang bertugas untuk menganalisis dan memproses suatu inputan dalam bentuk teks atau data
@@ -23,6 +27,7 @@ const (
 	PRODUCT         // *
 	PREFIX          // -X or !X
 	CALL            // myFunction(X)
+	INDEX           // array[index]
 )
 
 // Parser memiliki tiga bidang: l, curToken dan peekToken.
@@ -34,7 +39,19 @@ type Parser struct {
 	curlToken token.Token // curToken, yang merupakan token saat ini yang sedang diperiksa
 	peekToken token.Token //untuk memutuskan apa yang harus dilakukan selanjutnya, dan kita juga membutuhkan peekToken, untuk memutuskan apakah kita berada di akhir baris atau apakah kita apakah kita berada di awal ekspresi aritmatika.
 
-	erros []string
+	erros []diagnostic.Diagnostic
+
+	// panicking bernilai true sejak kesalahan pertama dalam sebuah pernyataan sampai parser
+	// melakukan sinkronisasi. Selama itu kesalahan lanjutan tidak dicatat, sehingga satu baris
+	// yang salah hanya menghasilkan satu diagnostic.
+	panicking bool
+	// blockDepth adalah kedalaman blok { } yang sedang diurai, digunakan saat sinkronisasi.
+	blockDepth int
+	// panicToken adalah token tempat kesalahan yang memulai panicking dilaporkan.
+	panicToken token.Token
+	// atBlockEnd bernilai true jika sinkronisasi berhenti tepat di } yang menutup blok yang sedang
+	// diurai. parseBlockStatement memakainya agar } tersebut tidak dilewati.
+	atBlockEnd bool
 
 	// 	Dengan adanya peta-peta ini, kita tinggal memeriksa apakah peta yang sesuai (infiks atau awalan) memiliki penguraian
 	// yang terkait dengan curToken.Type.
@@ -45,7 +62,7 @@ type Parser struct {
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:     l,
-		erros: []string{},
+		erros: []diagnostic.Diagnostic{},
 	}
 
 	p.nextToken()
@@ -57,6 +74,15 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 
 	p.registerPrefix(token.INT, p.parseIntegralLiteral)
+	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.TRUE, p.parseBoolean)
+	p.registerPrefix(token.FALSE, p.parseBoolean)
+	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
+	p.registerPrefix(token.IF, p.parseIfExpression)
+	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
+	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
+	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
 
 	// register prefix operator
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
@@ -72,6 +98,8 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
+	p.registerInfix(token.LPAREN, p.parseCallExpression)
+	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
 
 	return p
 }
@@ -98,7 +126,7 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program.Statements = []ast.Statement{}
 
 	for p.curlToken.Type != token.EOF {
-		stmt := p.parseStatement()
+		stmt := p.parseRecoverableStatement()
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -108,12 +136,83 @@ func (p *Parser) ParseProgram() *ast.Program {
 	return program
 }
 
+// parseRecoverableStatement mengurai satu pernyataan dan, jika terjadi kesalahan, melewati token
+// sampai titik sinkronisasi berikutnya (panic-mode recovery). Pernyataan yang strukturnya rusak
+// diganti dengan *ast.BadStatement yang mencakup semua token yang dilewati.
+func (p *Parser) parseRecoverableStatement() ast.Statement {
+	start := p.curlToken
+
+	stmt := p.parseStatement()
+	if !p.panicking {
+		return stmt
+	}
+
+	p.synchronize()
+	if stmt == nil {
+		return &ast.BadStatement{Token: start, To: p.curlToken.End}
+	}
+	return stmt
+}
+
+// synchronize memajukan token sampai p.curlToken adalah ; atau sampai token berikutnya adalah awal
+// pernyataan baru (let, return, if), } penutup blok yang sedang diurai, atau EOF.
+// Kurung kurawal yang dilewati dihitung, sehingga ; dan } di dalam blok yang rusak tidak dianggap
+// sebagai akhir pernyataan. Jika token yang menyebabkan kesalahan adalah } penutup blok itu sendiri
+// (misalnya `{ x + }`), sinkronisasi berhenti di } tersebut dan menandainya dengan atBlockEnd.
+func (p *Parser) synchronize() {
+	depth := 0
+
+	for !p.curlTokenIs(token.EOF) {
+		switch p.curlToken.Type {
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			if depth > 0 {
+				depth--
+			} else if p.blockDepth > 0 && p.curlToken.Pos == p.panicToken.Pos {
+				p.atBlockEnd = true
+				p.panicking = false
+				return
+			}
+		}
+
+		if depth == 0 {
+			if p.curlTokenIs(token.SEMICOLON) {
+				break
+			}
+
+			switch p.peekToken.Type {
+			case token.LET, token.RETURN, token.IF, token.EOF:
+				p.panicking = false
+				return
+			case token.RBRACE:
+				if p.blockDepth > 0 {
+					p.panicking = false
+					return
+				}
+			}
+		}
+
+		p.nextToken()
+	}
+
+	p.panicking = false
+}
+
+// parseStatement mengembalikan nil (bukan pointer nil yang dibungkus interface)
+// jika pernyataan let atau return tidak dapat diurai.
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.curlToken.Type {
 	case token.LET:
-		return p.parseLetStatement()
+		if stmt := p.parseLetStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
 	case token.RETURN:
-		return p.parseReturnStatement()
+		if stmt := p.parseReturnStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -135,22 +234,23 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 		return nil
 	}
 
-	// TODO: Kita melewatkan ekspresi sampai kita
-	// menemukan titik koma
-	for !p.curlTokenIs(token.SEMICOLON) {
-		p.nextToken()
-	}
+	// setelah tanda = kita mengurai ekspresi di sisi kanan.
+	// Titik koma di akhir bersifat opsional, sama seperti pada pernyataan ekspresi.
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	p.skipOptionalSemicolon()
 	return stmt
 }
 
+// parseReturnStatement mengurai ekspresi setelah kata kunci return ke dalam ReturnValue.
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	stmt := &ast.ReturnStatement{Token: p.curlToken}
 
 	p.nextToken()
+	stmt.ReturnValue = p.parseExpression(LOWEST)
 
-	for !p.curlTokenIs(token.SEMICOLON) {
-		p.nextToken()
-	}
+	p.skipOptionalSemicolon()
 	return stmt
 }
 
@@ -159,9 +259,7 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	stmt := &ast.ExpressionStatement{Token: p.curlToken}
 	stmt.Expression = p.parseExpression(LOWEST)
 
-	if p.peekTokenIs(token.SEMICOLON) {
-		p.nextToken()
-	}
+	p.skipOptionalSemicolon()
 	return stmt
 }
 
@@ -169,13 +267,21 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 // Yang dilakukannya adalah memeriksa apakah kita memiliki fungsi parsing yang terkait
 // dengan p.curToken.Type di posisi awalan. Jika ada, ia akan memanggil fungsi parsing ini, jika tidak ada, ia akan
 // mengembalikan nilai nol.
+//
+// Jika ekspresi tidak dapat diurai, hasilnya adalah *ast.BadExpression, bukan nil,
+// sehingga node induknya (misalnya LetStatement.Value) tidak pernah kosong.
 func (p *Parser) parseExpression(precedence int) ast.Expression {
+	start := p.curlToken
+
 	prefix := p.prefixParseFns[p.curlToken.Type]
 	if prefix == nil {
 		p.noPrefixParseFnError(p.curlToken.Type)
-		return nil
+		return &ast.BadExpression{Token: start, To: p.curlToken.End}
 	}
 	leftExp := prefix()
+	if leftExp == nil {
+		return &ast.BadExpression{Token: start, To: p.curlToken.End}
+	}
 
 	// 	mencoba menemukan infixParseFns untuk token berikutnya. Jika ia menemukan fungsi tersebut, ia akan memanggilnya, melewatkan dalam ekspresi yang dikembalikan oleh prefixParseFn sebagai argumen.
 	// Dan ia melakukan semua ini lagi dan lagi sampai ia menemukan token yang memiliki prioritas lebih tinggi.
@@ -186,11 +292,23 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		}
 		p.nextToken()
 		leftExp = infix(leftExp)
+		if leftExp == nil {
+			return &ast.BadExpression{Token: start, To: p.curlToken.End}
+		}
 
 	}
 	return leftExp
 }
 
+// skipOptionalSemicolon melewati ; opsional di akhir pernyataan let, return dan ekspresi.
+// Selama panicking, ; dibiarkan untuk synchronize, karena synchronize memakai posisi token
+// yang salah untuk membedakan } yang menutup blok saat ini dari } milik ekspresi di dalamnya.
+func (p *Parser) skipOptionalSemicolon() {
+	if !p.panicking && p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+}
+
 // menemukan titik koma/SEMICOLON
 func (p *Parser) curlTokenIs(t token.TokenType) bool {
 	return p.curlToken.Type == t
@@ -217,14 +335,61 @@ func (p *Parser) expectPeek(t token.TokenType) bool {
 	}
 }
 
-func (p *Parser) Errors() []string {
-	return p.erros
+// Errors mengembalikan semua kesalahan yang ditemukan selama parsing sebagai diagnostic.Diagnostic.
+// Setiap diagnostic menyimpan kode kesalahan dan span token yang menyebabkannya,
+// sehingga dapat dicetak dengan diagnostic.Render.
+// Kesalahan leksikal dari lexer ikut disertakan, diurutkan berdasarkan posisinya di kode sumber.
+func (p *Parser) Errors() []diagnostic.Diagnostic {
+	errors := append([]diagnostic.Diagnostic{}, p.l.Errors()...)
+	errors = append(errors, p.erros...)
+
+	sort.SliceStable(errors, func(i, j int) bool {
+		return errors[i].Span.Start.Offset < errors[j].Span.Start.Offset
+	})
+	return errors
+}
+
+// addError mencatat kesalahan dengan span yang mencakup token tok.
+// Selama parser dalam keadaan panicking, kesalahan lanjutan diabaikan. Token ILEGAL yang sudah
+// dilaporkan oleh lexer (misalnya byte UTF-8 yang tidak valid) tetap memulai panicking, tetapi
+// tidak dilaporkan dua kali.
+func (p *Parser) addError(code diagnostic.Code, tok token.Token, hint string, format string, a ...interface{}) {
+	if p.panicking {
+		return
+	}
+	p.panicking = true
+	p.panicToken = tok
+
+	if tok.Type == token.ILEGAL && p.hasLexerError(tok.Pos.Offset) {
+		return
+	}
+
+	d := diagnostic.New(code, diagnostic.Span{Start: tok.Pos, End: tok.End}, format, a...)
+	d.Hint = hint
+	p.erros = append(p.erros, d)
+}
+
+// hasLexerError melaporkan apakah lexer sudah mencatat kesalahan yang dimulai di offset.
+func (p *Parser) hasLexerError(offset int) bool {
+	for _, d := range p.l.Errors() {
+		if d.Span.Start.Offset == offset {
+			return true
+		}
+	}
+	return false
+}
+
+// expectHints berisi saran perbaikan untuk token yang paling sering terlupa.
+var expectHints = map[token.TokenType]string{
+	token.ASSIGN: "let bindings have the form `let <name> = <expression>;`",
+	token.RPAREN: "every `(` needs a matching `)`",
+	token.LBRACE: "blocks must be wrapped in `{` and `}`",
 }
 
 // memeriksa apakah parser menemukan kesalahan apa pun.
 func (p *Parser) peekError(t token.TokenType) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s instead", t, p.peekToken.Type)
-	p.erros = append(p.erros, msg)
+	p.addError(diagnostic.UnexpectedToken, p.peekToken, expectHints[t],
+		"expected next token to be %s, got %s instead", t, p.peekToken.Type)
 }
 
 // Parsing Expressions
@@ -289,13 +454,35 @@ func (p *Parser) registerInfix(tokenType token.TokenType, fn infixParseFn) {
 
 // Seperti parseIdentifier, metode ini sangat sederhana. Satu-satunya hal yang benar-benar berbeda adalah pemanggilan strconv.ParseInt, yang mengubah string di p.curToken.Literal menjadi
 // int64. Int64 tersebut kemudian disimpan ke dalam bidang Value dan kita mengembalikan * simpul *ast.IntegerLiteral. Jika tidak berhasil, kita menambahkan kesalahan baru ke dalam kesalahan parser field
+//
+// Literal dengan awalan 0x, 0o dan 0b dibaca dalam basis 16, 8 dan 2. Literal tanpa awalan selalu desimal,
+// jadi 010 adalah sepuluh (bukan oktal seperti di Go). Tanda _ hanya boleh berada di antara angka.
+// Literal yang tidak muat di int64 disimpan sebagai *big.Int di lit.Big.
 func (p *Parser) parseIntegralLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.curlToken}
 
-	value, err := strconv.ParseInt(p.curlToken.Literal, 0, 64)
+	value, ok := parseInteger(p.curlToken.Literal)
+	if !ok {
+		p.addError(diagnostic.InvalidInteger, p.curlToken, numberHint(p.curlToken.Literal), "could not parse %q as integer", p.curlToken.Literal)
+		return nil
+	}
+
+	if value.IsInt64() {
+		lit.Value = value.Int64()
+	} else {
+		lit.Big = value
+	}
+	return lit
+}
+
+// parseFloatLiteral mengubah literal seperti 3.14 atau 1e-9 menjadi float64.
+// Literal yang terlalu besar untuk float64 (misalnya 1e400) dilaporkan sebagai kesalahan.
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.curlToken}
+
+	value, err := strconv.ParseFloat(p.curlToken.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.curlToken.Literal)
-		p.erros = append(p.erros, msg)
+		p.addError(diagnostic.InvalidFloat, p.curlToken, numberHint(p.curlToken.Literal), "could not parse %q as float", p.curlToken.Literal)
 		return nil
 	}
 
@@ -303,6 +490,23 @@ func (p *Parser) parseIntegralLiteral() ast.Expression {
 	return lit
 }
 
+func parseInteger(literal string) (*big.Int, bool) {
+	if len(literal) > 1 && literal[0] == '0' && strings.ContainsRune("xXoObB", rune(literal[1])) {
+		return new(big.Int).SetString(literal, 0)
+	}
+	if strings.HasSuffix(literal, "_") || strings.Contains(literal, "__") {
+		return nil, false
+	}
+	return new(big.Int).SetString(strings.ReplaceAll(literal, "_", ""), 10)
+}
+
+func numberHint(literal string) string {
+	if strings.Contains(literal, "_") {
+		return "`_` may only appear between digits, as in 1_000_000"
+	}
+	return ""
+}
+
 // Prefix Operators
 // Ada dua operator awalan dalam bahasa pemrograman CokLang: ! dan -.
 // Penggunaan mereka adalah hampir sama dengan apa yang Anda harapkan dari bahasa-bahasa lain:
@@ -318,8 +522,11 @@ func (p *Parser) parseIntegralLiteral() ast.Expression {
 // noPrefixParseFnError hanya menambahkan pesan kesalahan yang diformat ke
 // bidang kesalahan pada parser kita. Tetapi itu cukup untuk mendapatkan pesan kesalahan yang lebih baik dalam pengujian yang gagal
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
-	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.erros = append(p.erros, msg)
+	hint := fmt.Sprintf("`%s` cannot start an expression", p.curlToken.Literal)
+	if t == token.EOF {
+		hint = "the input ends before the expression is complete"
+	}
+	p.addError(diagnostic.NoPrefixParseFn, p.curlToken, hint, "no prefix parse function for %s found", t)
 }
 
 // Untuk token.BANG dan token.MINUS kita mendaftarkan metode yang sama dengan prefixParseFn:
@@ -367,6 +574,8 @@ var precedences = map[token.TokenType]int{
 	token.MINUS:    SUM,
 	token.SLASH:    PRODUCT,
 	token.ASTERISK: PRODUCT,
+	token.LPAREN:   CALL,
+	token.LBRACKET: INDEX,
 }
 
 // Metode peekPrecedence mengembalikan prioritas yang terkait dengan tipe token p.peekToken.
@@ -403,3 +612,290 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	expression.Right = p.parseExpression(precedence)
 	return expression
 }
+
+// parseBoolean membangun *ast.Boolean dari token saat ini.
+// Nilainya true jika token saat ini adalah token.TRUE, selain itu false.
+func (p *Parser) parseBoolean() ast.Expression {
+	return &ast.Boolean{Token: p.curlToken, Value: p.curlTokenIs(token.TRUE)}
+}
+
+// Grouped Expressions
+// (5 + 5) * 2
+// Tanda kurung tidak menghasilkan node AST sendiri. Kita cukup mengurai ekspresi di dalamnya
+// dengan precedence LOWEST, sehingga ekspresi di dalam kurung selalu "diikat" lebih kuat
+// daripada operator di luarnya, lalu memastikan ada token.RPAREN penutup.
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	p.nextToken()
+
+	exp := p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	return exp
+}
+
+// If Expressions
+// if (<condition>) <consequence> else <alternative>
+// parseIfExpression memastikan kondisi diapit oleh ( dan ), lalu konsekuensi diawali {.
+// Jika salah satu token tersebut tidak ada, expectPeek menambahkan kesalahan yang menyebutkan
+// token mana yang diharapkan, sehingga pesan kesalahan menunjuk ke kurung atau kurawal yang hilang.
+func (p *Parser) parseIfExpression() ast.Expression {
+	expression := &ast.IfExpression{Token: p.curlToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+	expression.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	expression.Consequence = p.parseBlockStatement()
+
+	if p.peekTokenIs(token.ELSE) {
+		p.nextToken()
+
+		// else if (...) { ... } diurai sebagai IfExpression bersarang yang dibungkus
+		// dalam BlockStatement, sehingga evaluator tidak membutuhkan kasus khusus.
+		if p.peekTokenIs(token.IF) {
+			p.nextToken()
+			block := &ast.BlockStatement{Token: p.curlToken}
+			stmt := &ast.ExpressionStatement{Token: p.curlToken}
+			stmt.Expression = p.parseIfExpression()
+			if stmt.Expression == nil {
+				return nil
+			}
+			block.Statements = []ast.Statement{stmt}
+			expression.Alternative = block
+			return expression
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+
+		expression.Alternative = p.parseBlockStatement()
+	}
+
+	return expression
+}
+
+// parseBlockStatement memanggil parseStatement sampai menemukan } yang menandakan akhir blok,
+// atau token.EOF yang berarti kurung kurawal penutup tidak pernah ditemukan.
+func (p *Parser) parseBlockStatement() *ast.BlockStatement {
+	block := &ast.BlockStatement{Token: p.curlToken}
+	block.Statements = []ast.Statement{}
+
+	p.blockDepth++
+	defer func() { p.blockDepth-- }()
+
+	p.nextToken()
+
+	for !p.curlTokenIs(token.RBRACE) && !p.curlTokenIs(token.EOF) {
+		stmt := p.parseRecoverableStatement()
+		if stmt != nil {
+			block.Statements = append(block.Statements, stmt)
+		}
+		if p.atBlockEnd {
+			p.atBlockEnd = false
+			break
+		}
+		p.nextToken()
+	}
+
+	if p.curlTokenIs(token.EOF) {
+		p.addError(diagnostic.UnclosedBlock, p.curlToken, fmt.Sprintf("the block opened at %s is never closed", block.Token.Pos),
+			"expected %s to close block, got %s instead", token.RBRACE, p.curlToken.Type)
+	} else {
+		block.Rbrace = p.curlToken
+	}
+
+	return block
+}
+
+// Function Literals
+// fn(x, y) { return x + y; }
+// parseFunctionLiteral mengharapkan ( setelah kata kunci fn, mengurai daftar parameter,
+// lalu mengharapkan { sebagai awal badan fungsi.
+func (p *Parser) parseFunctionLiteral() ast.Expression {
+	lit := &ast.FunctionLiteral{Token: p.curlToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	lit.Parameters = p.parseFunctionParameters()
+	if lit.Parameters == nil {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	lit.Body = p.parseBlockStatement()
+
+	return lit
+}
+
+// parseFunctionParameters mengurai identifier yang dipisahkan koma sampai menemukan ).
+// Koma di akhir daftar diperbolehkan: fn(a, b,) { ... } sama dengan fn(a, b) { ... }.
+// Mengembalikan nil jika terjadi kesalahan, dan slice kosong jika fungsi tidak memiliki parameter.
+func (p *Parser) parseFunctionParameters() []*ast.Identifier {
+	identifiers := []*ast.Identifier{}
+
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return identifiers
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	identifiers = append(identifiers, &ast.Identifier{Token: p.curlToken, Value: p.curlToken.Literal})
+
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+
+		if p.peekTokenIs(token.RPAREN) {
+			break
+		}
+
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		identifiers = append(identifiers, &ast.Identifier{Token: p.curlToken, Value: p.curlToken.Literal})
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return identifiers
+}
+
+// Call Expressions
+// add(2, 3)
+// ( dalam posisi infix berarti pemanggilan fungsi: ekspresi di sebelah kirinya adalah fungsi
+// yang dipanggil. Karena itu token.LPAREN didaftarkan sebagai operator infix dengan precedence CALL.
+func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	exp := &ast.CallExpression{Token: p.curlToken, Function: function}
+	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	if exp.Arguments == nil {
+		return nil
+	}
+	exp.Rparen = p.curlToken
+	return exp
+}
+
+// parseExpressionList mengurai ekspresi yang dipisahkan koma sampai menemukan token end.
+// Sama seperti parameter fungsi, koma di akhir daftar diperbolehkan.
+func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
+	list := []ast.Expression{}
+
+	if p.peekTokenIs(end) {
+		p.nextToken()
+		return list
+	}
+
+	p.nextToken()
+	list = append(list, p.parseExpression(LOWEST))
+
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+
+		if p.peekTokenIs(end) {
+			break
+		}
+
+		p.nextToken()
+		list = append(list, p.parseExpression(LOWEST))
+	}
+
+	if !p.expectPeek(end) {
+		return nil
+	}
+
+	return list
+}
+
+// parseStringLiteral membangun *ast.StringLiteral. Escape sequence sudah diterjemahkan oleh lexer,
+// sehingga Value cukup diambil dari literal token.
+func (p *Parser) parseStringLiteral() ast.Expression {
+	return &ast.StringLiteral{Token: p.curlToken, Value: p.curlToken.Literal}
+}
+
+// Array Literals
+// [1, 2 * 2, fn(x) { x }]
+// Elemen array diurai dengan parseExpressionList yang sama dengan argumen pemanggilan fungsi,
+// hanya saja diakhiri oleh ] dan bukan ).
+func (p *Parser) parseArrayLiteral() ast.Expression {
+	array := &ast.ArrayLiteral{Token: p.curlToken}
+
+	array.Elements = p.parseExpressionList(token.RBRACKET)
+	if array.Elements == nil {
+		return nil
+	}
+	array.Rbracket = p.curlToken
+	return array
+}
+
+// Index Expressions
+// myArray[0]
+// [ dalam posisi infix berarti akses indeks. Precedence INDEX lebih tinggi dari CALL,
+// sehingga a * b[2] diurai sebagai (a * (b[2])) dan add(a)[0] sebagai ((add(a))[0]).
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	exp := &ast.IndexExpression{Token: p.curlToken, Left: left}
+
+	p.nextToken()
+	exp.Index = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+	exp.Rbracket = p.curlToken
+	return exp
+}
+
+// Hash Literals
+// {"name": "Thorsten", "age": 28}
+// { dalam posisi awalan (prefix) berarti literal hash. Kunci dan nilai boleh berupa ekspresi apa pun;
+// pemeriksaan apakah kunci dapat di-hash dilakukan saat evaluasi.
+// Pasangan disimpan sesuai urutan di kode sumber, dan koma di akhir diperbolehkan.
+func (p *Parser) parseHashLiteral() ast.Expression {
+	hash := &ast.HashLiteral{Token: p.curlToken}
+	hash.Pairs = []ast.HashPair{}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		key := p.parseExpression(LOWEST)
+
+		if !p.expectPeek(token.COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs = append(hash.Pairs, ast.HashPair{Key: key, Value: value})
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	hash.Rbrace = p.curlToken
+
+	return hash
+}
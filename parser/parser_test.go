@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"go-intepreter/ast"
+	"go-intepreter/diagnostic"
 	"go-intepreter/lexer"
 	"os"
 	"testing"
@@ -25,12 +26,13 @@ func TestLetStatement(t *testing.T) {
 
 	tests := []struct {
 		expectedIdentifier string
+		expectedValue      interface{}
 	}{
 
-		{"x"},
-		{"y"},
-		{"foobar"},
-		{"a"},
+		{"x", 5},
+		{"y", 10},
+		{"foobar", 838383},
+		{"a", stringLiteral("test")},
 	}
 
 	for i, tt := range tests {
@@ -38,6 +40,68 @@ func TestLetStatement(t *testing.T) {
 		if !testLetStatement(t, stmt, tt.expectedIdentifier) {
 			return
 		}
+
+		val := stmt.(*ast.LetStatement).Value
+		if !testLiteralExpression(t, val, tt.expectedValue) {
+			return
+		}
+	}
+}
+
+func TestLetStatementValues(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedIdentifier string
+		expectedValue      interface{}
+	}{
+		{"let x = 5;", "x", 5},
+		{"let y = 10", "y", 10},
+		{"let foobar = 838383;", "foobar", 838383},
+		{"let a = foobar;", "a", "foobar"},
+		{`let s = "test";`, "s", stringLiteral("test")},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("%q: program.Statements does not contain 1 statement. got=%d", tt.input, len(program.Statements))
+		}
+
+		stmt := program.Statements[0]
+		if !testLetStatement(t, stmt, tt.expectedIdentifier) {
+			return
+		}
+
+		val := stmt.(*ast.LetStatement).Value
+		if !testLiteralExpression(t, val, tt.expectedValue) {
+			return
+		}
+	}
+}
+
+func TestLetAndReturnWithoutSemicolon(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 5 * 2", "let x = (5 * 2);"},
+		{"return x + 1", "return (x + 1);"},
+		{"let y = x\nreturn y", "let y = x;return y;"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
 	}
 }
 
@@ -50,7 +114,7 @@ func checkParserErrors(t *testing.T, p *Parser) {
 
 	t.Errorf("parser has %d errors", len(errors))
 	for _, msg := range errors {
-		t.Errorf("parser error:%q", msg)
+		t.Errorf("parser error:%q", msg.String())
 	}
 	t.FailNow()
 }
@@ -94,7 +158,9 @@ func TestReturnStatement(t *testing.T) {
 		t.Fatalf("program.Statements does contain 3 statement. got=%d", len(program.Statements))
 	}
 
-	for _, stmt := range program.Statements {
+	expectedValues := []int64{5, 10, 993322}
+
+	for i, stmt := range program.Statements {
 		returnStmt, ok := stmt.(*ast.ReturnStatement)
 		if !ok {
 			t.Errorf("stmt not *ast.returnStatement. got=%T", stmt)
@@ -104,6 +170,8 @@ func TestReturnStatement(t *testing.T) {
 		if returnStmt.TokenLiteral() != "return" {
 			t.Errorf("returnStatement.TokenLiteral not 'return'. got=%q", returnStmt.TokenLiteral())
 		}
+
+		testIntegerLiteral(t, returnStmt.ReturnValue, expectedValues[i])
 	}
 }
 
@@ -168,6 +236,134 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestIntegerLiteralBases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"1_000_000", 1000000},
+		{"010", 10},
+		{"0xFF", 255},
+		{"0x_ff", 255},
+		{"0o755", 493},
+		{"0b1010_0101", 165},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Value != tt.expected {
+			t.Errorf("input %q: literal.Value not %d. got=%d", tt.input, tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestBigIntegerLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"99999999999999999999", "99999999999999999999"},
+		{"9_223_372_036_854_775_808", "9223372036854775808"},
+		{"0xFFFF_FFFF_FFFF_FFFF_FFFF", "1208925819614629174706175"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Big == nil || literal.Big.String() != tt.expected {
+			t.Errorf("input %q: literal.Big not %s. got=%v", tt.input, tt.expected, literal.Big)
+		}
+	}
+
+	// literal yang muat di int64 tidak memakai Big
+	l := lexer.New("9223372036854775807")
+	p := New(l)
+	program := p.ParseProgram()
+	literal := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IntegerLiteral)
+	if literal.Big != nil || literal.Value != 9223372036854775807 {
+		t.Errorf("max int64 should fit in Value. got Value=%d Big=%v", literal.Value, literal.Big)
+	}
+}
+
+func TestFloatLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14", 3.14},
+		{"0.5", 0.5},
+		{"1e-9", 1e-9},
+		{"2.5E+3", 2500},
+		{"1_000.000_1", 1000.0001},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.FloatLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.FloatLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Value != tt.expected {
+			t.Errorf("literal.Value not %g. got=%g", tt.expected, literal.Value)
+		}
+		if literal.String() != tt.input {
+			t.Errorf("literal.String() not %q. got=%q", tt.input, literal.String())
+		}
+	}
+}
+
+func TestNumberLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		code    diagnostic.Code
+		message string
+	}{
+		{"1__000", diagnostic.InvalidInteger, `1:1: could not parse "1__000" as integer`},
+		{"1_000_", diagnostic.InvalidInteger, `1:1: could not parse "1_000_" as integer`},
+		{"0x", diagnostic.InvalidInteger, `1:1: could not parse "0x" as integer`},
+		{"1e400", diagnostic.InvalidFloat, `1:1: could not parse "1e400" as float`},
+		{"1_.5", diagnostic.InvalidFloat, `1:1: could not parse "1_.5" as float`},
+		{".5", diagnostic.NoPrefixParseFn, "1:1: no prefix parse function for ILEGAL found"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("input %q: expected parser errors, got none", tt.input)
+			continue
+		}
+		if errors[0].Code != tt.code || errors[0].String() != tt.message {
+			t.Errorf("input %q: wrong error. expected=%s %q, got=%s %q", tt.input, tt.code, tt.message, errors[0].Code, errors[0].String())
+		}
+	}
+}
+
 func TestParsingPrefixExpresssion(t *testing.T) {
 	prefixTests := []struct {
 		input        string
@@ -299,6 +495,25 @@ func TestOperatorPrecendenceParsing(t *testing.T) {
 		{"5 > 4 != 3 > 4", "((5 > 4) != (3 > 4))"},
 		{"3 + 4 * 5 == 3 * 1 + 4 * 5", "((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))"},
 		{"3 + 4 * 5 == 3 * 1 + 4 * 5", "((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))"},
+		{"true", "true"},
+		{"false", "false"},
+		{"3 > 5 == false", "((3 > 5) == false)"},
+		{"3 < 5 == true", "((3 < 5) == true)"},
+		{"!true != false", "((!true) != false)"},
+		{"1 + (2 + 3) + 4", "((1 + (2 + 3)) + 4)"},
+		{"(5 + 5) * 2", "((5 + 5) * 2)"},
+		{"2 / (5 + 5)", "(2 / (5 + 5))"},
+		{"-(5 + 5)", "(-(5 + 5))"},
+		{"!(true == true)", "(!(true == true))"},
+		{"(a * (b - c)) / -d", "((a * (b - c)) / (-d))"},
+		{"((1))", "1"},
+		{"a + add(b * c) + d", "((a + add((b * c))) + d)"},
+		{"add(a, b, 1, 2 * 3, 4 + 5, add(6, 7 * 8))", "add(a, b, 1, (2 * 3), (4 + 5), add(6, (7 * 8)))"},
+		{"add(a + b + c * d / f + g)", "add((((a + b) + ((c * d) / f)) + g))"},
+		{"a * [1, 2, 3, 4][b * c] * d", "((a * ([1, 2, 3, 4][(b * c)])) * d)"},
+		{"add(a * b[2], b[1], 2 * [1, 2][1])", "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))"},
+		{"-a[0]", "(-(a[0]))"},
+		{"get(x)[0]", "(get(x)[0])"},
 	}
 
 	for _, tt := range tests {
@@ -314,3 +529,983 @@ func TestOperatorPrecendenceParsing(t *testing.T) {
 		}
 	}
 }
+
+func testIdentifier(t *testing.T, exp ast.Expression, value string) bool {
+	ident, ok := exp.(*ast.Identifier)
+	if !ok {
+		t.Errorf("exp not *ast.Identifier. got=%T", exp)
+		return false
+	}
+
+	if ident.Value != value {
+		t.Errorf("ident.Value not %s. got=%s", value, ident.Value)
+		return false
+	}
+
+	if ident.TokenLiteral() != value {
+		t.Errorf("ident.TokenLiteral not %s. got=%s", value, ident.TokenLiteral())
+		return false
+	}
+	return true
+}
+
+// stringLiteral membedakan nilai string yang diharapkan dari nama identifier pada testLiteralExpression.
+type stringLiteral string
+
+// testLiteralExpression memilih helper yang sesuai berdasarkan tipe nilai yang diharapkan.
+func testLiteralExpression(t *testing.T, exp ast.Expression, expected interface{}) bool {
+	switch v := expected.(type) {
+	case int:
+		return testIntegerLiteral(t, exp, int64(v))
+	case int64:
+		return testIntegerLiteral(t, exp, v)
+	case string:
+		return testIdentifier(t, exp, v)
+	case bool:
+		return testBooleanLiteral(t, exp, v)
+	case stringLiteral:
+		return testStringLiteral(t, exp, string(v))
+	}
+	t.Errorf("type of exp not handled. got=%T", exp)
+	return false
+}
+
+func TestBooleanExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"true;", true},
+		{"false;", false},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program has not enough statements. got=%d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Statements[0])
+		}
+
+		testLiteralExpression(t, stmt.Expression, tt.expected)
+	}
+}
+
+func TestParsingBooleanInfixExpression(t *testing.T) {
+	tests := []struct {
+		input      string
+		leftValue  interface{}
+		operator   string
+		rightValue interface{}
+	}{
+		{"true == true", true, "==", true},
+		{"true != false", true, "!=", false},
+		{"false == false", false, "==", false},
+		{"!true;", nil, "!", true},
+		{"!false;", nil, "!", false},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		switch exp := stmt.Expression.(type) {
+		case *ast.InfixExpression:
+			testLiteralExpression(t, exp.Left, tt.leftValue)
+			if exp.Operator != tt.operator {
+				t.Errorf("exp.Operator is not '%s'. got=%s", tt.operator, exp.Operator)
+			}
+			testLiteralExpression(t, exp.Right, tt.rightValue)
+		case *ast.PrefixExpression:
+			if exp.Operator != tt.operator {
+				t.Errorf("exp.Operator is not '%s'. got=%s", tt.operator, exp.Operator)
+			}
+			testLiteralExpression(t, exp.Right, tt.rightValue)
+		default:
+			t.Errorf("unexpected expression type. got=%T", stmt.Expression)
+		}
+	}
+}
+
+func TestGroupedExpressionMissingParen(t *testing.T) {
+	l := lexer.New("(1 + 2")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) == 0 {
+		t.Fatalf("expected parser error for missing ')'")
+	}
+
+	expected := "1:7: expected next token to be ), got EOF instead"
+	if errors[0].String() != expected {
+		t.Errorf("wrong error. expected=%q, got=%q", expected, errors[0].String())
+	}
+}
+
+func testBooleanLiteral(t *testing.T, exp ast.Expression, value bool) bool {
+	bo, ok := exp.(*ast.Boolean)
+	if !ok {
+		t.Errorf("exp not *ast.Boolean. got=%T", exp)
+		return false
+	}
+
+	if bo.Value != value {
+		t.Errorf("bo.Value not %t. got=%t", value, bo.Value)
+		return false
+	}
+
+	if bo.TokenLiteral() != fmt.Sprintf("%t", value) {
+		t.Errorf("bo.TokenLiteral not %t. got=%s", value, bo.TokenLiteral())
+		return false
+	}
+	return true
+}
+
+func TestIfExpression(t *testing.T) {
+	input := `if (x < y) { x }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d", 1, len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	if !testInfixExpression(t, exp.Condition, "x", "<", "y") {
+		return
+	}
+
+	if len(exp.Consequence.Statements) != 1 {
+		t.Errorf("consequence is not 1 statements. got=%d", len(exp.Consequence.Statements))
+	}
+
+	consequence, ok := exp.Consequence.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Statements[0] is not ast.ExpressionStatement. got=%T", exp.Consequence.Statements[0])
+	}
+
+	if !testIdentifier(t, consequence.Expression, "x") {
+		return
+	}
+
+	if exp.Alternative != nil {
+		t.Errorf("exp.Alternative was not nil. got=%+v", exp.Alternative)
+	}
+}
+
+func TestIfElseExpression(t *testing.T) {
+	// sama dengan ekspresi if pada lexer/scenario4.cok
+	input := `if (5 < 10) {
+return true;
+} else {
+return false;
+}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	if !testInfixExpression(t, exp.Condition, 5, "<", 10) {
+		return
+	}
+
+	consequence, ok := exp.Consequence.Statements[0].(*ast.ReturnStatement)
+	if !ok {
+		t.Fatalf("Consequence.Statements[0] is not ast.ReturnStatement. got=%T", exp.Consequence.Statements[0])
+	}
+	testLiteralExpression(t, consequence.ReturnValue, true)
+
+	if exp.Alternative == nil {
+		t.Fatalf("exp.Alternative is nil")
+	}
+
+	alternative, ok := exp.Alternative.Statements[0].(*ast.ReturnStatement)
+	if !ok {
+		t.Fatalf("Alternative.Statements[0] is not ast.ReturnStatement. got=%T", exp.Alternative.Statements[0])
+	}
+	testLiteralExpression(t, alternative.ReturnValue, false)
+
+	expected := "if (5 < 10) { return true; } else { return false; }"
+	if exp.String() != expected {
+		t.Errorf("exp.String() wrong. expected=%q, got=%q", expected, exp.String())
+	}
+}
+
+func TestElseIfChain(t *testing.T) {
+	input := `if (x == 0) { 0 } else if (x == 1) { 1 } else { 2 }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expected := "if (x == 0) { 0 } else if (x == 1) { 1 } else { 2 }"
+	if program.String() != expected {
+		t.Errorf("program.String() wrong. expected=%q, got=%q", expected, program.String())
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp := stmt.Expression.(*ast.IfExpression)
+
+	nested, ok := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Alternative.Statements[0] is not ast.ExpressionStatement. got=%T", exp.Alternative.Statements[0])
+	}
+
+	elseIf, ok := nested.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("else branch is not ast.IfExpression. got=%T", nested.Expression)
+	}
+
+	if !testInfixExpression(t, elseIf.Condition, "x", "==", 1) {
+		return
+	}
+
+	if elseIf.Alternative == nil {
+		t.Errorf("elseIf.Alternative is nil")
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	tests := []string{
+		"if (x) { 1 }",
+		"if (!ok) { return 1; } else { 2 }",
+		"if (x == 0) { 0 } else if (x == 1) { 1 } else { 2 }",
+		"let f = fn(a, b) { let c = a + b; c };",
+		"fn() { a; (b) }",
+		"fn() { }",
+		`if (s == "a\"b") { puts(s) }`,
+	}
+
+	for _, input := range tests {
+		first := New(lexer.New(input)).ParseProgram().String()
+
+		p := New(lexer.New(first))
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != first {
+			t.Errorf("String() does not round-trip for %q. first=%q, second=%q", input, first, program.String())
+		}
+	}
+}
+
+func TestIfExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"if x < y) { x }", "1:4: expected next token to be (, got IDENT instead"},
+		{"if (x < y { x }", "1:11: expected next token to be ), got { instead"},
+		{"if (x < y) x }", "1:12: expected next token to be {, got IDENT instead"},
+		{"if (x < y) { x } else y", "1:23: expected next token to be {, got IDENT instead"},
+		{"if (x < y) { x ", "1:16: expected } to close block, got EOF instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("input %q: expected parser errors, got none", tt.input)
+			continue
+		}
+
+		if errors[0].String() != tt.expected {
+			t.Errorf("input %q: wrong error. expected=%q, got=%q", tt.input, tt.expected, errors[0].String())
+		}
+	}
+}
+
+func testInfixExpression(t *testing.T, exp ast.Expression, left interface{}, operator string, right interface{}) bool {
+	opExp, ok := exp.(*ast.InfixExpression)
+	if !ok {
+		t.Errorf("exp is not ast.InfixExpression. got=%T(%s)", exp, exp)
+		return false
+	}
+
+	if !testLiteralExpression(t, opExp.Left, left) {
+		return false
+	}
+
+	if opExp.Operator != operator {
+		t.Errorf("exp.Operator is not '%s'. got=%q", operator, opExp.Operator)
+		return false
+	}
+
+	if !testLiteralExpression(t, opExp.Right, right) {
+		return false
+	}
+	return true
+}
+
+func TestFunctionLiteralAndCallFromScenario(t *testing.T) {
+	cokFile := "../lexer/scenario1.cok"
+	file, _ := os.ReadFile(cokFile)
+
+	l := lexer.New(string(file))
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 4 {
+		t.Fatalf("program.Statements does not contain 4 statements. got=%d", len(program.Statements))
+	}
+
+	addStmt := program.Statements[2].(*ast.LetStatement)
+	if !testLetStatement(t, addStmt, "add") {
+		return
+	}
+
+	function, ok := addStmt.Value.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("addStmt.Value is not ast.FunctionLiteral. got=%T", addStmt.Value)
+	}
+
+	if len(function.Parameters) != 2 {
+		t.Fatalf("function literal parameters wrong. want 2, got=%d", len(function.Parameters))
+	}
+
+	testLiteralExpression(t, function.Parameters[0], "x")
+	testLiteralExpression(t, function.Parameters[1], "y")
+
+	if len(function.Body.Statements) != 1 {
+		t.Fatalf("function.Body.Statements has not 1 statements. got=%d", len(function.Body.Statements))
+	}
+
+	bodyStmt, ok := function.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("function body stmt is not ast.ExpressionStatement. got=%T", function.Body.Statements[0])
+	}
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+
+	resultStmt := program.Statements[3].(*ast.LetStatement)
+	if !testLetStatement(t, resultStmt, "result") {
+		return
+	}
+
+	call, ok := resultStmt.Value.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("resultStmt.Value is not ast.CallExpression. got=%T", resultStmt.Value)
+	}
+
+	if !testIdentifier(t, call.Function, "add") {
+		return
+	}
+
+	if len(call.Arguments) != 2 {
+		t.Fatalf("wrong length of arguments. got=%d", len(call.Arguments))
+	}
+
+	testLiteralExpression(t, call.Arguments[0], "five")
+	testLiteralExpression(t, call.Arguments[1], "ten")
+
+	expected := "let five = 5;let ten = 10;let add = fn(x, y) { (x + y) };let result = add(five, ten);"
+	if program.String() != expected {
+		t.Errorf("program.String() wrong. expected=%q, got=%q", expected, program.String())
+	}
+}
+
+func TestFunctionParameterParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{input: "fn() {};", expectedParams: []string{}},
+		{input: "fn(x) {};", expectedParams: []string{"x"}},
+		{input: "fn(x, y, z) {};", expectedParams: []string{"x", "y", "z"}},
+		{input: "fn(x, y,) {};", expectedParams: []string{"x", "y"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(function.Parameters) != len(tt.expectedParams) {
+			t.Errorf("length parameters wrong. want %d, got=%d", len(tt.expectedParams), len(function.Parameters))
+		}
+
+		for i, ident := range tt.expectedParams {
+			testLiteralExpression(t, function.Parameters[i], ident)
+		}
+	}
+}
+
+func TestCallExpressionArgumentParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"add();", "add()"},
+		{"add(1);", "add(1)"},
+		{"add(1, 2 * 3, 4 + 5);", "add(1, (2 * 3), (4 + 5))"},
+		{"add(1, 2,);", "add(1, 2)"},
+		{"fn(x) { x; }(5)", "fn(x) { x }(5)"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestFunctionAndCallErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(x, 1) {}", "1:7: expected next token to be IDENT, got INT instead"},
+		{"fn(x,,) {}", "1:6: expected next token to be IDENT, got , instead"},
+		{"fn(x y) {}", "1:6: expected next token to be ), got IDENT instead"},
+		{"fn x {}", "1:4: expected next token to be (, got IDENT instead"},
+		{"add(1, 2", "1:9: expected next token to be ), got EOF instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("input %q: expected parser errors, got none", tt.input)
+			continue
+		}
+
+		if errors[0].String() != tt.expected {
+			t.Errorf("input %q: wrong error. expected=%q, got=%q", tt.input, tt.expected, errors[0].String())
+		}
+	}
+}
+
+func TestNodePositions(t *testing.T) {
+	input := `let add = fn(a, b) {
+  a + b;
+};
+add(1, 2);`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	tests := []struct {
+		node     ast.Node
+		pos, end string
+	}{
+		{program, "1:1", "4:10"},
+		{program.Statements[0], "1:1", "3:2"},
+		{program.Statements[0].(*ast.LetStatement).Value, "1:11", "3:2"},
+		{program.Statements[1], "4:1", "4:10"},
+		{program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression).Arguments[1], "4:8", "4:9"},
+	}
+
+	for i, tt := range tests {
+		if tt.node.Pos().String() != tt.pos {
+			t.Errorf("test[%d] %q: Pos wrong. expected=%s, got=%s", i, tt.node, tt.pos, tt.node.Pos())
+		}
+		if tt.node.End().String() != tt.end {
+			t.Errorf("test[%d] %q: End wrong. expected=%s, got=%s", i, tt.node, tt.end, tt.node.End())
+		}
+	}
+
+	body := program.Statements[0].(*ast.LetStatement).Value.(*ast.FunctionLiteral).Body.Statements[0]
+	if body.Pos().String() != "2:3" || body.End().String() != "2:8" {
+		t.Errorf("body statement span wrong. got=%s-%s", body.Pos(), body.End())
+	}
+}
+
+func TestErrorsIncludeFilename(t *testing.T) {
+	l := lexer.New("let x 5;", lexer.WithFilename("scenario.cok"))
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) == 0 {
+		t.Fatalf("expected parser errors, got none")
+	}
+
+	expected := "scenario.cok:1:7: expected next token to be =, got INT instead"
+	if errors[0].String() != expected {
+		t.Errorf("wrong error. expected=%q, got=%q", expected, errors[0].String())
+	}
+}
+
+func TestDiagnosticCodes(t *testing.T) {
+	tests := []struct {
+		input string
+		code  diagnostic.Code
+		start string
+		end   string
+	}{
+		{"let x 5;", diagnostic.UnexpectedToken, "1:7", "1:8"},
+		{"let x = );", diagnostic.NoPrefixParseFn, "1:9", "1:10"},
+		{"99_999__999", diagnostic.InvalidInteger, "1:1", "1:12"},
+		{"if (true) { 1", diagnostic.UnclosedBlock, "1:14", "1:14"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("input %q: expected parser errors, got none", tt.input)
+			continue
+		}
+
+		d := errors[0]
+		if d.Code != tt.code {
+			t.Errorf("input %q: wrong code. expected=%s, got=%s", tt.input, tt.code, d.Code)
+		}
+		if d.Severity != diagnostic.Error {
+			t.Errorf("input %q: wrong severity. got=%s", tt.input, d.Severity)
+		}
+		if d.Span.Start.String() != tt.start || d.Span.End.String() != tt.end {
+			t.Errorf("input %q: wrong span. expected=%s-%s, got=%s-%s", tt.input, tt.start, tt.end, d.Span.Start, d.Span.End)
+		}
+	}
+}
+
+func TestIllegalTokenReportedOnce(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let \xff = 2;\nlet y = 3;", "1:5: invalid UTF-8 byte 0xff"},
+		{"let x = \xff;", "1:9: invalid UTF-8 byte 0xff"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Errorf("input %q: expected 1 error, got=%d (%v)", tt.input, len(errors), errors)
+			continue
+		}
+		if errors[0].Code != diagnostic.InvalidUTF8 || errors[0].String() != tt.expected {
+			t.Errorf("input %q: wrong error. expected=%q, got=%s %q", tt.input, tt.expected, errors[0].Code, errors[0].String())
+		}
+		if len(program.Statements) == 0 {
+			t.Errorf("input %q: expected statements after recovery", tt.input)
+		}
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	input := `let x = 5;
+let = 10;
+let y = x + ;
+let z = add(1, 2);
+if (x < y { x } else { y }
+return z;`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	errors := p.Errors()
+	expectedErrors := []string{
+		"2:5: expected next token to be IDENT, got = instead",
+		"3:13: no prefix parse function for ; found",
+		"5:11: expected next token to be ), got { instead",
+	}
+
+	if len(errors) != len(expectedErrors) {
+		for _, e := range errors {
+			t.Logf("parser error: %s", e)
+		}
+		t.Fatalf("wrong number of errors. expected=%d, got=%d", len(expectedErrors), len(errors))
+	}
+
+	for i, expected := range expectedErrors {
+		if errors[i].String() != expected {
+			t.Errorf("errors[%d] wrong. expected=%q, got=%q", i, expected, errors[i].String())
+		}
+	}
+
+	expectedStatements := []string{
+		"let x = 5;",
+		"<bad statement>",
+		"let y = (x + <bad expression>);",
+		"let z = add(1, 2);",
+		"<bad expression>",
+		"return z;",
+	}
+
+	if len(program.Statements) != len(expectedStatements) {
+		t.Fatalf("wrong number of statements. expected=%d, got=%d (%q)", len(expectedStatements), len(program.Statements), program.String())
+	}
+
+	for i, expected := range expectedStatements {
+		if program.Statements[i].String() != expected {
+			t.Errorf("statements[%d] wrong. expected=%q, got=%q", i, expected, program.Statements[i].String())
+		}
+	}
+
+	bad, ok := program.Statements[1].(*ast.BadStatement)
+	if !ok {
+		t.Fatalf("program.Statements[1] is not ast.BadStatement. got=%T", program.Statements[1])
+	}
+
+	if bad.Pos().String() != "2:1" || bad.End().String() != "2:10" {
+		t.Errorf("bad statement span wrong. got=%s-%s", bad.Pos(), bad.End())
+	}
+}
+
+func TestErrorRecoveryInsideBlock(t *testing.T) {
+	input := `let f = fn(a) {
+  let = a;
+  return a * 2;
+};
+let g = 1;`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 1 {
+		t.Fatalf("expected 1 error, got=%d (%v)", len(p.Errors()), p.Errors())
+	}
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("wrong number of statements. expected=2, got=%d", len(program.Statements))
+	}
+
+	function := program.Statements[0].(*ast.LetStatement).Value.(*ast.FunctionLiteral)
+	if len(function.Body.Statements) != 2 {
+		t.Fatalf("function body has wrong number of statements. expected=2, got=%d", len(function.Body.Statements))
+	}
+
+	if _, ok := function.Body.Statements[0].(*ast.BadStatement); !ok {
+		t.Errorf("body.Statements[0] is not ast.BadStatement. got=%T", function.Body.Statements[0])
+	}
+
+	if function.Body.Statements[1].String() != "return (a * 2);" {
+		t.Errorf("body.Statements[1] wrong. got=%q", function.Body.Statements[1].String())
+	}
+
+	testLetStatement(t, program.Statements[1], "g")
+}
+
+func TestErrorRecoveryAtClosingBrace(t *testing.T) {
+	input := "let f = fn(x) { x + };\nlet g = 2;\ng"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 error, got=%d (%v)", len(errors), errors)
+	}
+	if errors[0].Code != diagnostic.NoPrefixParseFn || errors[0].String() != "1:21: no prefix parse function for } found" {
+		t.Errorf("wrong error. got=%s %q", errors[0].Code, errors[0].String())
+	}
+
+	if len(program.Statements) != 3 {
+		t.Fatalf("wrong number of statements. expected=3, got=%d (%q)", len(program.Statements), program.String())
+	}
+
+	function := program.Statements[0].(*ast.LetStatement).Value.(*ast.FunctionLiteral)
+	if len(function.Body.Statements) != 1 {
+		t.Errorf("function body has wrong number of statements. expected=1, got=%d", len(function.Body.Statements))
+	}
+
+	testLetStatement(t, program.Statements[1], "g")
+	if program.Statements[2].String() != "g" {
+		t.Errorf("statements[2] wrong. got=%q", program.Statements[2].String())
+	}
+}
+
+func testStringLiteral(t *testing.T, exp ast.Expression, value string) bool {
+	str, ok := exp.(*ast.StringLiteral)
+	if !ok {
+		t.Errorf("exp not *ast.StringLiteral. got=%T", exp)
+		return false
+	}
+
+	if str.Value != value {
+		t.Errorf("str.Value not %q. got=%q", value, str.Value)
+		return false
+	}
+	return true
+}
+
+func TestStringLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		str      string
+	}{
+		{`"hello world";`, "hello world", `"hello world"`},
+		{`"baris\nbaru\t\"kutip\" \\";`, "baris\nbaru\t\"kutip\" \\", `"baris\nbaru\t\"kutip\" \\"`},
+		{`"\u{48}\u{e9}\u{1F600}";`, "Hé😀", `"Hé😀"`},
+		{`"";`, "", `""`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		if !testStringLiteral(t, stmt.Expression, tt.expected) {
+			continue
+		}
+
+		if stmt.String() != tt.str {
+			t.Errorf("String() wrong. expected=%q, got=%q", tt.str, stmt.String())
+		}
+	}
+}
+
+func TestStringLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		code     diagnostic.Code
+		expected string
+	}{
+		{`let a = "test;`, diagnostic.UnterminatedString, "1:9: unterminated string literal"},
+		{`"a\qb";`, diagnostic.InvalidEscape, "1:3: unknown escape sequence \\q"},
+		{`"\u{110000}";`, diagnostic.InvalidEscape, "1:2: invalid unicode code point \\u{110000}"},
+		{`"\u48";`, diagnostic.InvalidEscape, "1:2: expected { after \\u"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("input %q: expected errors, got none", tt.input)
+			continue
+		}
+
+		if errors[0].Code != tt.code {
+			t.Errorf("input %q: wrong code. expected=%s, got=%s", tt.input, tt.code, errors[0].Code)
+		}
+
+		if errors[0].String() != tt.expected {
+			t.Errorf("input %q: wrong error. expected=%q, got=%q", tt.input, tt.expected, errors[0].String())
+		}
+	}
+}
+
+func TestParsingArrayLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"[1, 2 * 2, 3 + 3]", 3},
+		{"[]", 0},
+		{"[1, 2,]", 2},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		array, ok := stmt.Expression.(*ast.ArrayLiteral)
+		if !ok {
+			t.Fatalf("exp not ast.ArrayLiteral. got=%T", stmt.Expression)
+		}
+
+		if len(array.Elements) != tt.expected {
+			t.Fatalf("len(array.Elements) not %d. got=%d", tt.expected, len(array.Elements))
+		}
+	}
+
+	l := lexer.New("[1, 2 * 2, 3 + 3]")
+	p := New(l)
+	program := p.ParseProgram()
+	array := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ArrayLiteral)
+
+	testIntegerLiteral(t, array.Elements[0], 1)
+	testInfixExpression(t, array.Elements[1], 2, "*", 2)
+	testInfixExpression(t, array.Elements[2], 3, "+", 3)
+
+	if array.Pos().String() != "1:1" || array.End().String() != "1:18" {
+		t.Errorf("array span wrong. got=%s-%s", array.Pos(), array.End())
+	}
+}
+
+func TestParsingIndexExpressions(t *testing.T) {
+	input := "myArray[1 + 1]"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	indexExp, ok := stmt.Expression.(*ast.IndexExpression)
+	if !ok {
+		t.Fatalf("exp not *ast.IndexExpression. got=%T", stmt.Expression)
+	}
+
+	if !testIdentifier(t, indexExp.Left, "myArray") {
+		return
+	}
+
+	if !testInfixExpression(t, indexExp.Index, 1, "+", 1) {
+		return
+	}
+
+	if indexExp.Pos().String() != "1:1" || indexExp.End().String() != "1:15" {
+		t.Errorf("index span wrong. got=%s-%s", indexExp.Pos(), indexExp.End())
+	}
+}
+
+func TestArrayAndIndexErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2", "1:6: expected next token to be ], got EOF instead"},
+		{"a[1", "1:4: expected next token to be ], got EOF instead"},
+		{"a[]", "1:3: no prefix parse function for ] found"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Errorf("input %q: expected 1 error, got=%d (%v)", tt.input, len(errors), errors)
+			continue
+		}
+
+		if errors[0].String() != tt.expected {
+			t.Errorf("input %q: wrong error. expected=%q, got=%q", tt.input, tt.expected, errors[0].String())
+		}
+	}
+}
+
+func TestParsingHashLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"one": 1, "two": 2, "three": 3}`, `{"one": 1, "two": 2, "three": 3}`},
+		{"{}", "{}"},
+		{`{"one": 0 + 1, "two": 10 - 8, "three": 15 / 5}`, `{"one": (0 + 1), "two": (10 - 8), "three": (15 / 5)}`},
+		{`{1: true, true: "x", "a" + "b": [1],}`, `{1: true, true: "x", ("a" + "b"): [1]}`},
+		{`let thorsten = {"name": "Thorsten", "age": 28}; thorsten["name"]`, `let thorsten = {"name": "Thorsten", "age": 28};(thorsten["name"])`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	l := lexer.New(`{"one": 1, "two": 2}`)
+	p := New(l)
+	program := p.ParseProgram()
+	hash, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("exp is not ast.HashLiteral. got=%T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	expected := []struct {
+		key   string
+		value int64
+	}{{"one", 1}, {"two", 2}}
+
+	if len(hash.Pairs) != len(expected) {
+		t.Fatalf("hash.Pairs has wrong length. got=%d", len(hash.Pairs))
+	}
+
+	for i, e := range expected {
+		testStringLiteral(t, hash.Pairs[i].Key, e.key)
+		testIntegerLiteral(t, hash.Pairs[i].Value, e.value)
+	}
+}
+
+func TestHashLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"a" 1}`, "1:6: expected next token to be :, got INT instead"},
+		{`{"a": 1 "b": 2}`, "1:9: expected next token to be ,, got STRING instead"},
+		{`{"a": 1`, "1:8: expected next token to be ,, got EOF instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Errorf("input %q: expected 1 error, got=%d (%v)", tt.input, len(errors), errors)
+			continue
+		}
+
+		if errors[0].String() != tt.expected {
+			t.Errorf("input %q: wrong error. expected=%q, got=%q", tt.input, tt.expected, errors[0].String())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package repl
+
+import (
+	"go-intepreter/diagnostic"
+	"go-intepreter/lexer"
+	"go-intepreter/token"
+)
+
+// isIncomplete melaporkan apakah src masih membutuhkan baris lanjutan, yaitu jika
+// masih ada (, [ atau { yang belum ditutup, string yang belum diakhiri tanda kutip,
+// atau komentar blok yang belum ditutup.
+// Pemeriksaan dilakukan dengan lexer, sehingga kurung di dalam string tidak ikut dihitung.
+// Kurung penutup yang berlebih membuat input dianggap lengkap agar parser dapat melaporkan kesalahannya.
+func isIncomplete(src string) bool {
+	l := lexer.New(src)
+	depth := 0
+
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		switch tok.Type {
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+
+	for _, d := range l.Errors() {
+		if d.Code == diagnostic.UnterminatedString || d.Code == diagnostic.UnterminatedComment {
+			return true
+		}
+	}
+
+	return depth > 0
+}
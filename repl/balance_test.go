@@ -0,0 +1,32 @@
+package repl
+
+import "testing"
+
+func TestIsIncomplete(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"let x = 5;", false},
+		{"", false},
+		{"let add = fn(a, b) {", true},
+		{"let add = fn(a, b) {\n  a + b;\n};", false},
+		{"add(1,", true},
+		{"[1, 2,\n 3", true},
+		{"{\"a\": [1, {\"b\": 2}]", true},
+		{`"unterminated`, true},
+		{"\"multi\nline\"", false},
+		{`"(" + "["`, false},
+		{"let x = 5; }", false},
+		{"if (x) { 1 }}", false},
+		{"/* comment", true},
+		{"/* comment */ let x = 1;", false},
+		{"let x = 1; // {", false},
+	}
+
+	for _, tt := range tests {
+		if got := isIncomplete(tt.input); got != tt.expected {
+			t.Errorf("isIncomplete(%q) wrong. expected=%t, got=%t", tt.input, tt.expected, got)
+		}
+	}
+}
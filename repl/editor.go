@@ -0,0 +1,396 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// errInterrupted dikembalikan ketika pengguna menekan Ctrl-C; input yang sedang diketik dibuang.
+var errInterrupted = errors.New("interrupted")
+
+// kode tombol kontrol yang dikenali editor baris
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLF        = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyCR        = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEsc       = 27
+	keyBackspace = 127
+)
+
+// editor adalah editor baris sederhana ala readline untuk terminal dalam mode raw.
+// editor tidak mengatur mode terminal sendiri sehingga dapat diuji dengan reader dan writer biasa.
+//
+// Tombol yang didukung: panah kiri/kanan, Home/End, Ctrl-A/E/B/F, Backspace, Delete, Ctrl-D,
+// Ctrl-K/U/W, Ctrl-L, panah atas/bawah atau Ctrl-P/N untuk riwayat, Ctrl-R untuk mencari
+// riwayat, dan Tab untuk melengkapi kata kunci serta nama yang terikat di environment.
+type editor struct {
+	in      *bufio.Reader
+	out     io.Writer
+	history *history
+	words   func() []string // kandidat untuk tab completion
+
+	prompt  string
+	buf     []rune
+	pos     int
+	histIdx int    // posisi saat menelusuri riwayat; len(history.entries) berarti baris baru
+	saved   string // baris yang sedang diketik sebelum menelusuri riwayat
+}
+
+func newEditor(in io.Reader, out io.Writer, h *history, words func() []string) *editor {
+	return &editor{in: bufio.NewReader(in), out: out, history: h, words: words}
+}
+
+// readLine menampilkan prompt dan membaca satu baris. Mengembalikan io.EOF jika Ctrl-D
+// ditekan pada baris kosong dan errInterrupted jika Ctrl-C ditekan.
+func (e *editor) readLine(prompt string) (string, error) {
+	e.prompt, e.buf, e.pos = prompt, nil, 0
+	e.histIdx, e.saved = len(e.history.entries), ""
+	e.refresh()
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case keyCR, keyLF:
+			return e.finish(), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(e.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			e.delete(e.pos, e.pos+1)
+		case keyCtrlA:
+			e.pos = 0
+		case keyCtrlE:
+			e.pos = len(e.buf)
+		case keyCtrlB:
+			e.move(-1)
+		case keyCtrlF:
+			e.move(1)
+		case keyBackspace, keyCtrlH:
+			if e.pos > 0 {
+				e.delete(e.pos-1, e.pos)
+			}
+		case keyCtrlK:
+			e.delete(e.pos, len(e.buf))
+		case keyCtrlU:
+			e.delete(0, e.pos)
+		case keyCtrlW:
+			start := e.pos
+			for start > 0 && unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			e.delete(start, e.pos)
+		case keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case keyCtrlP:
+			e.browse(-1)
+		case keyCtrlN:
+			e.browse(1)
+		case keyTab:
+			e.complete()
+		case keyCtrlR:
+			submit, err := e.reverseSearch()
+			if err != nil {
+				return "", err
+			}
+			if submit {
+				return e.finish(), nil
+			}
+		case keyEsc:
+			if err := e.escape(); err != nil {
+				return "", err
+			}
+		default:
+			if unicode.IsPrint(r) {
+				e.insert([]rune{r})
+			}
+		}
+		e.refresh()
+	}
+}
+
+// finish mengakhiri baris yang sedang diedit dan menyimpannya ke riwayat.
+func (e *editor) finish() string {
+	fmt.Fprint(e.out, "\r\n")
+	line := string(e.buf)
+	// kegagalan menulis file riwayat tidak boleh menghentikan REPL
+	_ = e.history.add(line)
+	return line
+}
+
+// escape menangani urutan escape ANSI untuk tombol panah, Home, End dan Delete.
+// Urutan CSI berbentuk ESC [ <parameter> <byte akhir>, dengan parameter berupa angka yang
+// dipisahkan ; (misalnya ESC [ 3 ~ untuk Delete, atau ESC [ 1 ; 5 C untuk Ctrl-Right).
+// Semua byte dibaca sampai byte akhir (0x40-0x7E) agar sisa urutan tidak masuk ke baris;
+// tombol ditentukan dari byte akhir dan parameter pertama, sedangkan pengubah seperti Ctrl diabaikan.
+func (e *editor) escape() error {
+	r, _, err := e.in.ReadRune()
+	if err != nil {
+		return err
+	}
+	if r != '[' && r != 'O' {
+		return nil
+	}
+
+	var params string
+	for {
+		r, _, err = e.in.ReadRune()
+		if err != nil {
+			return err
+		}
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+		params += string(r)
+	}
+
+	code := strings.SplitN(params, ";", 2)[0]
+	switch r {
+	case '~':
+		switch code {
+		case "1", "7":
+			e.pos = 0
+		case "4", "8":
+			e.pos = len(e.buf)
+		case "3":
+			e.delete(e.pos, e.pos+1)
+		}
+	case 'A':
+		e.browse(-1)
+	case 'B':
+		e.browse(1)
+	case 'C':
+		e.move(1)
+	case 'D':
+		e.move(-1)
+	case 'H':
+		e.pos = 0
+	case 'F':
+		e.pos = len(e.buf)
+	}
+	return nil
+}
+
+func (e *editor) refresh() {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", e.prompt, string(e.buf))
+	if n := len(e.buf) - e.pos; n > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", n)
+	}
+}
+
+func (e *editor) bell() {
+	fmt.Fprint(e.out, "\a")
+}
+
+func (e *editor) move(delta int) {
+	pos := e.pos + delta
+	if pos < 0 || pos > len(e.buf) {
+		return
+	}
+	e.pos = pos
+}
+
+func (e *editor) insert(rs []rune) {
+	buf := make([]rune, 0, len(e.buf)+len(rs))
+	buf = append(buf, e.buf[:e.pos]...)
+	buf = append(buf, rs...)
+	e.buf = append(buf, e.buf[e.pos:]...)
+	e.pos += len(rs)
+}
+
+// delete menghapus rune dari indeks start sampai end (tidak termasuk).
+func (e *editor) delete(start, end int) {
+	if end > len(e.buf) {
+		end = len(e.buf)
+	}
+	if start >= end {
+		return
+	}
+	e.buf = append(e.buf[:start], e.buf[end:]...)
+	if e.pos > end {
+		e.pos -= end - start
+	} else if e.pos > start {
+		e.pos = start
+	}
+}
+
+func (e *editor) setLine(line string) {
+	e.buf = []rune(line)
+	e.pos = len(e.buf)
+}
+
+// browse berpindah ke entri riwayat sebelumnya (delta -1) atau berikutnya (delta 1).
+func (e *editor) browse(delta int) {
+	idx := e.histIdx + delta
+	if idx < 0 || idx > len(e.history.entries) {
+		e.bell()
+		return
+	}
+
+	if e.histIdx == len(e.history.entries) {
+		e.saved = string(e.buf)
+	}
+	e.histIdx = idx
+
+	if idx == len(e.history.entries) {
+		e.setLine(e.saved)
+	} else {
+		e.setLine(e.history.entries[idx])
+	}
+}
+
+// complete melengkapi kata di depan kursor. Jika ada beberapa kandidat, bagian yang sama
+// dari semua kandidat disisipkan; jika tidak ada yang bisa disisipkan, kandidat dicetak.
+func (e *editor) complete() {
+	start := e.pos
+	for start > 0 && isWordRune(e.buf[start-1]) {
+		start--
+	}
+	prefix := string(e.buf[start:e.pos])
+	if prefix == "" {
+		e.bell()
+		return
+	}
+
+	matches := completions(prefix, e.words())
+	if len(matches) == 0 {
+		e.bell()
+		return
+	}
+
+	common := matches[0]
+	for _, m := range matches[1:] {
+		common = commonPrefix(common, m)
+	}
+
+	if len(common) > len(prefix) {
+		e.insert([]rune(common[len(prefix):]))
+		return
+	}
+	if len(matches) > 1 {
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(matches, "  "))
+	}
+}
+
+// reverseSearch menjalankan pencarian riwayat ala Ctrl-R pada bash. Enter menjalankan entri
+// yang ditemukan, Ctrl-G atau Ctrl-C membatalkan, dan tombol kontrol lainnya menyalin entri
+// ke baris agar dapat diedit. Mengembalikan true jika baris harus langsung dijalankan.
+func (e *editor) reverseSearch() (bool, error) {
+	original, originalPos := e.buf, e.pos
+	query := ""
+	match := -1
+
+	for {
+		found := ""
+		if match >= 0 {
+			found = e.history.entries[match]
+		}
+		fmt.Fprintf(e.out, "\r(reverse-i-search)`%s': %s\x1b[K", query, found)
+
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case r == keyCtrlR:
+			if match <= 0 {
+				e.bell()
+				continue
+			}
+			if next := e.history.search(query, match-1); next >= 0 {
+				match = next
+			} else {
+				e.bell()
+			}
+		case r == keyBackspace || r == keyCtrlH:
+			if query == "" {
+				continue
+			}
+			q := []rune(query)
+			query = string(q[:len(q)-1])
+			match = e.history.search(query, len(e.history.entries)-1)
+		case r == keyCtrlG || r == keyCtrlC:
+			e.buf, e.pos = original, originalPos
+			return false, nil
+		case r == keyCR || r == keyLF:
+			if match >= 0 {
+				e.setLine(found)
+			}
+			return true, nil
+		case unicode.IsPrint(r):
+			query += string(r)
+			from := match
+			if from < 0 {
+				from = len(e.history.entries) - 1
+			}
+			match = e.history.search(query, from)
+			if match < 0 {
+				e.bell()
+			}
+		default:
+			if match >= 0 {
+				e.setLine(found)
+				e.histIdx = match
+			}
+			return false, nil
+		}
+	}
+}
+
+// completions mengembalikan kata-kata unik dari words yang diawali prefix, diurutkan.
+func completions(prefix string, words []string) []string {
+	seen := make(map[string]bool)
+	var matches []string
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) && !seen[w] {
+			seen[w] = true
+			matches = append(matches, w)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+func commonPrefix(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	i := 0
+	for i < len(ra) && i < len(rb) && ra[i] == rb[i] {
+		i++
+	}
+	return string(ra[:i])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,132 @@
+package repl
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestEditor(input string, entries ...string) *editor {
+	h := &history{}
+	for _, e := range entries {
+		h.append(e)
+	}
+	words := func() []string {
+		return []string{"fn", "let", "return", "result", "reset_count"}
+	}
+	return newEditor(strings.NewReader(input), io.Discard, h, words)
+}
+
+func TestEditorKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		history  []string
+		expected string
+	}{
+		{"plain", "let a = 1;\r", nil, "let a = 1;"},
+		{"backspace", "lett\x7f a\r", nil, "let a"},
+		{"left arrow insert", "ac\x1b[Db\r", nil, "abc"},
+		{"home and end", "bc\x1b[Ha\x1b[Fd\r", nil, "abcd"},
+		{"ctrl-a ctrl-e", "bc\x01a\x05d\r", nil, "abcd"},
+		{"delete key", "abc\x01\x1b[3~\r", nil, "bc"},
+		{"home and end with tilde", "bc\x1b[1~a\x1b[4~d\r", nil, "abcd"},
+		{"ctrl-right with parameters", "ac\x01\x1b[1;5Cb\r", nil, "abc"},
+		{"ctrl-left with parameters", "ac\x1b[1;5Db\r", nil, "abc"},
+		{"ss3 arrow", "ac\x1bODb\r", nil, "abc"},
+		{"ctrl-k", "abcdef\x02\x02\x02\x0b\r", nil, "abc"},
+		{"ctrl-u", "abcdef\x02\x02\x15\r", nil, "ef"},
+		{"ctrl-w", "let foo bar\x17\r", nil, "let foo "},
+		{"unicode", "\"héllo\"\x1b[D\x7f\r", nil, "\"héll\""},
+		{"history up", "\x1b[A\x1b[A\r", []string{"first", "second"}, "first"},
+		{"history up down", "new\x10\x0e\r", []string{"first"}, "new"},
+		{"history edit", "\x1b[A + 1\r", []string{"let x = 1"}, "let x = 1 + 1"},
+		{"reverse search", "\x12x =\r", []string{"let x = 1", "let y = 2", "puts(x)"}, "let x = 1"},
+		{"reverse search again", "\x12let\x12\r", []string{"let x = 1", "let y = 2"}, "let x = 1"},
+		{"reverse search edit", "\x12y\x06;\r", []string{"let y = 2", "z"}, "let y = 2;"},
+		{"reverse search cancel", "abc\x12y\x07\r", []string{"let y = 2"}, "abc"},
+		{"complete keyword", "le\t x\r", nil, "let x"},
+		{"complete common prefix", "res\tu\t\r", nil, "result"},
+		{"complete name", "1 + res\tet\t\r", nil, "1 + reset_count"},
+		{"complete no match", "zz\t\r", nil, "zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEditor(tt.input, tt.history...)
+			line, err := e.readLine(PROMPT)
+			if err != nil {
+				t.Fatalf("readLine returned error: %s", err)
+			}
+			if line != tt.expected {
+				t.Errorf("wrong line. expected=%q, got=%q", tt.expected, line)
+			}
+		})
+	}
+}
+
+func TestEditorEOFAndInterrupt(t *testing.T) {
+	e := newTestEditor("\x04")
+	if _, err := e.readLine(PROMPT); err != io.EOF {
+		t.Errorf("ctrl-d on empty line: expected io.EOF, got %v", err)
+	}
+
+	e = newTestEditor("abc\x03")
+	if _, err := e.readLine(PROMPT); err != errInterrupted {
+		t.Errorf("ctrl-c: expected errInterrupted, got %v", err)
+	}
+
+	e = newTestEditor("ab\x01\x04\r")
+	line, err := e.readLine(PROMPT)
+	if err != nil || line != "b" {
+		t.Errorf("ctrl-d on non-empty line should delete. got=%q, err=%v", line, err)
+	}
+}
+
+func TestEditorListsCompletions(t *testing.T) {
+	var out bytes.Buffer
+	h := &history{}
+	e := newEditor(strings.NewReader("res\t\t\r"), &out, h, func() []string {
+		return []string{"result", "reset", "let"}
+	})
+
+	if _, err := e.readLine(PROMPT); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "reset  result") {
+		t.Errorf("expected candidates to be listed, got %q", out.String())
+	}
+}
+
+func TestHistoryPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HISTORY_FILE)
+
+	h, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("missing history file should not be an error: %s", err)
+	}
+	for _, line := range []string{"let a = 1;", "", "a", "a", "a + 1"} {
+		if err := h.add(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "let a = 1;\na\na + 1\n" {
+		t.Errorf("wrong history file. got=%q", data)
+	}
+
+	reloaded, err := loadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(reloaded.entries, "|") != "let a = 1;|a|a + 1" {
+		t.Errorf("wrong reloaded entries. got=%q", reloaded.entries)
+	}
+}
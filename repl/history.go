@@ -0,0 +1,87 @@
+package repl
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// HISTORY_LIMIT adalah jumlah maksimum baris riwayat yang disimpan di memori.
+const HISTORY_LIMIT = 1000
+
+// history menyimpan baris yang pernah dimasukkan, dari yang terlama ke yang terbaru.
+// Jika path tidak kosong, setiap baris baru juga ditambahkan ke file tersebut
+// sehingga riwayat tetap ada pada sesi REPL berikutnya.
+type history struct {
+	entries []string
+	path    string
+}
+
+// loadHistory membaca riwayat dari path. File yang belum ada bukan kesalahan.
+func loadHistory(path string) (*history, error) {
+	h := &history{path: path}
+	if path == "" {
+		return h, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h.append(scanner.Text())
+	}
+	return h, scanner.Err()
+}
+
+// add menambahkan baris ke riwayat dan ke file riwayat. Baris kosong dan baris yang
+// sama dengan entri terakhir tidak disimpan.
+func (h *history) add(line string) error {
+	if !h.append(line) || h.path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(line + "\n")
+	return err
+}
+
+func (h *history) append(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == line {
+		return false
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) > HISTORY_LIMIT {
+		h.entries = h.entries[len(h.entries)-HISTORY_LIMIT:]
+	}
+	return true
+}
+
+// search mencari entri terbaru yang mengandung query, dimulai dari indeks from ke belakang.
+// Mengembalikan -1 jika tidak ada yang cocok.
+func (h *history) search(query string, from int) int {
+	if from >= len(h.entries) {
+		from = len(h.entries) - 1
+	}
+	for i := from; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,62 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// lineReader membaca satu baris input setelah menampilkan prompt.
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// newLineReader memilih editor baris jika in adalah terminal yang mendukung mode raw,
+// dan pembacaan baris biasa untuk pipe, file, atau reader lain (misalnya di test).
+func newLineReader(in io.Reader, out io.Writer, opts Options, words func() []string) lineReader {
+	if f, ok := in.(*os.File); ok {
+		if _, err := getTermState(f.Fd()); err == nil {
+			h, err := loadHistory(opts.HistoryFile)
+			if err != nil {
+				fmt.Fprintf(out, "cannot load history %s: %s\n", opts.HistoryFile, err)
+			}
+			return &terminalReader{fd: f.Fd(), editor: newEditor(f, out, h, words)}
+		}
+	}
+	return &scanReader{out: out, scanner: bufio.NewScanner(in)}
+}
+
+// scanReader membaca baris dengan bufio.Scanner tanpa fitur pengeditan.
+type scanReader struct {
+	out     io.Writer
+	scanner *bufio.Scanner
+}
+
+func (r *scanReader) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(r.out, prompt)
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+// terminalReader mengaktifkan mode raw hanya selama satu baris dibaca, sehingga keluaran
+// evaluasi dicetak dengan pengaturan terminal yang normal.
+type terminalReader struct {
+	fd     uintptr
+	editor *editor
+}
+
+func (r *terminalReader) ReadLine(prompt string) (string, error) {
+	state, err := makeRaw(r.fd)
+	if err != nil {
+		return "", err
+	}
+	defer restoreTerm(r.fd, state)
+
+	return r.editor.readLine(prompt)
+}
@@ -1,34 +1,249 @@
 package repl
 
 import (
-	"bufio"
 	"fmt"
+	"go-intepreter/ast"
+	"go-intepreter/diagnostic"
+	"go-intepreter/evaluator"
 	"go-intepreter/lexer"
+	"go-intepreter/object"
+	"go-intepreter/parser"
 	"go-intepreter/token"
 	"io"
+	"os"
+	"strings"
 )
 
 // Bahasa COK membutuhkan REPL. REPL adalah singkatan dari "Read Eval Print Loop"
 // Terkadang REPL disebut "konsol", terkadang "mode interaktif".
 // Konsepnya adalah sama: REPL REPL membaca input, mengirimkannya ke interpreter untuk dievaluasi, mencetak hasil/keluaran dari penerjemah dan memulai lagi. Baca, Evaluasi, Cetak, Ulangi.
-// Kita belum tahu bagaimana cara "mengevaluasi" kode sumber COK sepenuhnya.
-// Kita hanya memiliki satu bagian dari proses yang bersembunyi di balik "Eval":
+// Selain mengevaluasi, REPL juga dapat menampilkan token atau AST dari setiap baris.
+// Mode ini dapat diganti saat REPL berjalan dengan perintah meta yang diawali ":" (lihat :help).
 const PROMPT = ">> "
 
+// CONTINUATION_PROMPT ditampilkan selama input belum lengkap, misalnya ketika
+// badan fungsi yang ditempel (paste) masih memiliki { yang belum ditutup.
+const CONTINUATION_PROMPT = ".. "
+
+const HELP = `meta commands:
+  :eval         evaluate each line (default)
+  :tokens       print the tokens of each line
+  :ast          print the parsed statements of each line
+  :load <file>  evaluate a .cok file into the current environment
+  :env          list the current bindings
+  :reset        drop all bindings
+  :help         show this help`
+
+// mode menentukan apa yang dilakukan REPL terhadap setiap baris yang bukan perintah meta.
+type mode int
+
+const (
+	modeEval mode = iota
+	modeTokens
+	modeAST
+)
+
+var modeNames = map[mode]string{
+	modeEval:   "eval",
+	modeTokens: "tokens",
+	modeAST:    "ast",
+}
+
+// session menyimpan keadaan REPL di antara baris: mode yang aktif dan environment,
+// sehingga pengikatan let tetap ada di baris berikutnya.
+type session struct {
+	out  io.Writer
+	env  *object.Environment
+	mode mode
+}
+
+// Options mengatur tampilan REPL. Field yang kosong diganti dengan nilai bawaan.
+type Options struct {
+	Prompt             string // bawaan: PROMPT
+	ContinuationPrompt string // bawaan: CONTINUATION_PROMPT
+	HistoryFile        string // file riwayat editor baris; kosong berarti riwayat tidak disimpan
+}
+
+// HISTORY_FILE adalah nama file riwayat REPL di direktori home pengguna.
+const HISTORY_FILE = ".cok_history"
+
+// Start menjalankan REPL dengan Options bawaan.
 func Start(in io.Reader, out io.Writer) {
-	scanner := bufio.NewScanner(in)
+	StartWithOptions(in, out, Options{})
+}
+
+// StartWithOptions membaca baris dari in sampai habis. Semua keluaran (prompt, hasil evaluasi
+// dan pesan kesalahan) ditulis ke out, sehingga REPL dapat disematkan atau diuji tanpa stdout.
+// Jika in adalah terminal, baris dibaca dengan editor baris (riwayat, Ctrl-R dan tab completion);
+// selain itu baris dibaca apa adanya.
+func StartWithOptions(in io.Reader, out io.Writer, opts Options) {
+	if opts.Prompt == "" {
+		opts.Prompt = PROMPT
+	}
+	if opts.ContinuationPrompt == "" {
+		opts.ContinuationPrompt = CONTINUATION_PROMPT
+	}
 
+	s := &session{out: out, env: newEnvironment(out), mode: modeEval}
+	reader := newLineReader(in, out, opts, s.words)
+
+read:
 	for {
-		fmt.Printf("%s", PROMPT)
-		scanned := scanner.Scan()
-		if !scanned {
+		line, err := reader.ReadLine(opts.Prompt)
+		if err == errInterrupted {
+			continue
+		}
+		if err != nil {
 			return
 		}
 
-		line := scanner.Text()
-		l := lexer.New(line)
+		if strings.HasPrefix(strings.TrimSpace(line), ":") {
+			s.command(strings.TrimSpace(line))
+			continue
+		}
+
+		// baris berikutnya digabungkan selama kurung atau string masih terbuka
+		input := line
+		for isIncomplete(input) {
+			next, err := reader.ReadLine(opts.ContinuationPrompt)
+			if err == errInterrupted {
+				continue read
+			}
+			if err != nil {
+				break
+			}
+			input += "\n" + next
+		}
+
+		s.handle(input)
+	}
+}
+
+// command menjalankan perintah meta seperti :tokens atau :load file.cok.
+func (s *session) command(line string) {
+	fields := strings.Fields(line)
+	name, args := fields[0], fields[1:]
+
+	switch name {
+	case ":eval":
+		s.setMode(modeEval)
+	case ":tokens":
+		s.setMode(modeTokens)
+	case ":ast":
+		s.setMode(modeAST)
+	case ":load":
+		if len(args) != 1 {
+			fmt.Fprintln(s.out, "usage: :load <file.cok>")
+			return
+		}
+		s.load(args[0])
+	case ":env":
+		for _, name := range s.env.Names() {
+			val, _ := s.env.Get(name)
+			fmt.Fprintf(s.out, "%s = %s\n", name, inspect(val))
+		}
+	case ":reset":
+		s.env = newEnvironment(s.out)
+		fmt.Fprintln(s.out, "environment reset")
+	case ":help":
+		fmt.Fprintln(s.out, HELP)
+	default:
+		fmt.Fprintf(s.out, "unknown command %s, type :help for a list of commands\n", name)
+	}
+}
+
+// words mengembalikan kandidat tab completion: kata kunci, fungsi bawaan dan nama yang terikat saat ini.
+func (s *session) words() []string {
+	words := append(token.Keywords(), evaluator.BuiltinNames()...)
+	return append(words, s.env.Names()...)
+}
+
+// newEnvironment membuat environment sesi yang mengarahkan keluaran program (misalnya puts) ke out.
+func newEnvironment(out io.Writer) *object.Environment {
+	env := object.NewEnvironment()
+	env.SetOutput(out)
+	return env
+}
+
+func (s *session) setMode(m mode) {
+	s.mode = m
+	fmt.Fprintf(s.out, "mode: %s\n", modeNames[m])
+}
+
+// handle memproses satu baris sesuai mode yang aktif.
+func (s *session) handle(line string) {
+	switch s.mode {
+	case modeTokens:
+		l := lexer.New(line, lexer.WithComments())
 		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
-			fmt.Printf("%+v\n", tok)
+			fmt.Fprintf(s.out, "%+v\n", tok)
+		}
+		for _, d := range l.Errors() {
+			diagnostic.Render(s.out, line, d)
 		}
+	case modeAST:
+		program, ok := s.parse("", line)
+		if !ok {
+			return
+		}
+		for _, stmt := range program.Statements {
+			fmt.Fprintf(s.out, "%s-%s %T %s\n", stmt.Pos(), stmt.End(), stmt, stmt.String())
+		}
+	default:
+		program, ok := s.parse("", line)
+		if !ok {
+			return
+		}
+		s.eval(program)
+	}
+}
+
+// load mengevaluasi seluruh isi file ke dalam environment saat ini, apa pun mode yang aktif.
+func (s *session) load(path string) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(s.out, "cannot load %s: %s\n", path, err)
+		return
+	}
+
+	program, ok := s.parse(path, string(src))
+	if !ok {
+		return
+	}
+	s.eval(program)
+}
+
+// parse mengurai src dan mencetak kesalahan sintaks jika ada.
+func (s *session) parse(name, src string) (*ast.Program, bool) {
+	var opts []lexer.Option
+	if name != "" {
+		opts = append(opts, lexer.WithFilename(name))
+	}
+
+	p := parser.New(lexer.New(src, opts...))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		printParserErrors(s.out, src, p.Errors())
+		return nil, false
+	}
+	return program, true
+}
+
+func (s *session) eval(program *ast.Program) {
+	evaluated := evaluator.Eval(program, s.env)
+	if evaluated != nil {
+		fmt.Fprintln(s.out, evaluated.Inspect())
+	}
+}
+
+// printParserErrors mencetak setiap kesalahan parser beserta baris kode sumber dan penanda ^.
+func printParserErrors(out io.Writer, src string, errors []diagnostic.Diagnostic) {
+	diagnostic.RenderAll(out, src, errors)
+}
+
+func inspect(obj object.Object) string {
+	if obj == nil {
+		return "null"
 	}
+	return obj.Inspect()
 }
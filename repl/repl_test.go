@@ -0,0 +1,118 @@
+package repl
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// TestGoldenSessions memberikan setiap testdata/*.in sebagai input REPL dan membandingkan
+// seluruh keluaran dengan testdata/*.golden. Jalankan `go test ./repl -update` untuk
+// memperbarui file golden setelah perubahan yang disengaja.
+func TestGoldenSessions(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.in"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inputs) == 0 {
+		t.Fatalf("no testdata/*.in files found")
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".in")
+
+		t.Run(name, func(t *testing.T) {
+			in, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// :load menggunakan path relatif terhadap direktori testdata
+			wd, _ := os.Getwd()
+			if err := os.Chdir("testdata"); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(wd)
+
+			var out bytes.Buffer
+			Start(bytes.NewReader(in), &out)
+
+			golden := filepath.Join(wd, "testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("missing golden file, run with -update: %s", err)
+			}
+
+			if out.String() != string(expected) {
+				t.Errorf("transcript differs from %s\nexpected:\n%s\ngot:\n%s", golden, expected, out.String())
+			}
+		})
+	}
+}
+
+func TestCustomPrompt(t *testing.T) {
+	var out bytes.Buffer
+	StartWithOptions(strings.NewReader("let f = fn(x) {\nx };\n1 + 1\n"), &out, Options{
+		Prompt:             "cok> ",
+		ContinuationPrompt: "...  ",
+	})
+
+	expected := "cok> ...  cok> 2\ncok> "
+	if out.String() != expected {
+		t.Errorf("wrong transcript. expected=%q, got=%q", expected, out.String())
+	}
+}
+
+// TestMetaCommands menjalankan satu sesi berskrip yang memakai perintah meta bersama-sama:
+// :load mengisi environment yang dipakai baris berikutnya, :reset mengosongkannya,
+// dan mode tetap aktif sampai diganti.
+func TestMetaCommands(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lib.cok")
+	if err := os.WriteFile(file, []byte("let n = 41;\nlet inc = fn(x) { x + 1 };\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	script := strings.Join([]string{
+		":load " + file,
+		"inc(n)",
+		":env",
+		":reset",
+		":env",
+		"n",
+		"  :ast  ",
+		"let k = 2;",
+		"k",
+		":eval",
+		":help",
+	}, "\n")
+
+	var out bytes.Buffer
+	StartWithOptions(strings.NewReader(script), &out, Options{Prompt: "> "})
+
+	expected := "> > 42\n" +
+		"> inc = fn(x) { (x + 1) }\nn = 41\n" +
+		"> environment reset\n" +
+		"> " +
+		"> ERROR: 1:1: identifier not found: n\n" +
+		"> mode: ast\n" +
+		"> 1:1-1:10 *ast.LetStatement let k = 2;\n" +
+		"> 1:1-1:2 *ast.ExpressionStatement k\n" +
+		"> mode: eval\n" +
+		"> " + HELP + "\n" +
+		"> "
+	if out.String() != expected {
+		t.Errorf("wrong transcript.\nexpected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package repl
+
+import "errors"
+
+// di platform lain editor baris tidak tersedia dan REPL kembali membaca baris biasa.
+var errNoTerminal = errors.New("raw terminal mode is not supported on this platform")
+
+type termState struct{}
+
+func getTermState(fd uintptr) (*termState, error) { return nil, errNoTerminal }
+
+func makeRaw(fd uintptr) (*termState, error) { return nil, errNoTerminal }
+
+func restoreTerm(fd uintptr, st *termState) error { return errNoTerminal }
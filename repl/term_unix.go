@@ -0,0 +1,55 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package repl
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// termState menyimpan pengaturan terminal sebelum mode raw, agar dapat dipulihkan.
+type termState struct {
+	termios syscall.Termios
+}
+
+func getTermState(fd uintptr) (*termState, error) {
+	var st termState
+	if err := ioctl(fd, ioctlGetTermios, &st.termios); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// makeRaw mematikan echo, mode kanonik dan sinyal (Ctrl-C, Ctrl-Z) sehingga setiap tombol
+// langsung dibaca oleh editor baris. OPOST dibiarkan aktif agar "\n" tetap menjadi "\r\n".
+func makeRaw(fd uintptr) (*termState, error) {
+	old, err := getTermState(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := old.termios
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return old, nil
+}
+
+func restoreTerm(fd uintptr, st *termState) error {
+	return ioctl(fd, ioctlSetTermios, &st.termios)
+}
+
+func ioctl(fd, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
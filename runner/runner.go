@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"fmt"
+	"go-intepreter/diagnostic"
+	"go-intepreter/evaluator"
+	"go-intepreter/lexer"
+	"go-intepreter/object"
+	"go-intepreter/parser"
+	"io"
+	"os"
+)
+
+// Runner menjalankan program CokLang lengkap dari sebuah file atau stdin, bukan baris demi baris
+// seperti REPL. Prosesnya sama: lexing, parsing, lalu evaluasi. Bedanya, program tidak dijalankan
+// sama sekali jika ada kesalahan sintaks, dan hasilnya dilaporkan melalui kode keluar (exit status).
+
+// Kode keluar yang dikembalikan oleh Run dan RunFile.
+const (
+	ExitOK    = 0 // program selesai tanpa kesalahan
+	ExitError = 1 // kesalahan sintaks atau kesalahan runtime
+	ExitUsage = 2 // pemanggilan yang salah, misalnya file tidak dapat dibaca
+)
+
+// StdinName adalah nama file khusus yang berarti "baca program dari stdin".
+const StdinName = "-"
+
+// RunFile membaca program dari path (atau dari stdin jika path adalah "-") dan menjalankannya dengan Run.
+func RunFile(path string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		src []byte
+		err error
+	)
+
+	name := path
+	if path == StdinName {
+		name = "<stdin>"
+		src, err = io.ReadAll(stdin)
+	} else {
+		src, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "cok: %s\n", err)
+		return ExitUsage
+	}
+
+	return Run(name, string(src), args, stdout, stderr)
+}
+
+// Run mengurai dan mengevaluasi src. name digunakan sebagai nama file pada setiap posisi
+// di pesan kesalahan. args tersedia bagi program sebagai array string bernama args.
+// Kesalahan sintaks dicetak ke stderr dengan diagnostic.RenderAll, kesalahan runtime dengan
+// awalan "runtime error:", dan keduanya menghasilkan ExitError. Keluaran program (puts) ditulis ke stdout.
+func Run(name, src string, args []string, stdout, stderr io.Writer) int {
+	l := lexer.New(src, lexer.WithFilename(name))
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		diagnostic.RenderAll(stderr, src, p.Errors())
+		return ExitError
+	}
+
+	env := object.NewEnvironment()
+	env.SetOutput(stdout)
+	env.Set("args", newArgs(args))
+
+	result := evaluator.Eval(program, env)
+	if errObj, ok := result.(*object.Error); ok {
+		// kesalahan runtime membawa posisi node atau call site (file:line:column) jika ada
+		location := name
+		if errObj.Pos.IsValid() {
+			location = errObj.Pos.String()
+		}
+		fmt.Fprintf(stderr, "%s: runtime error: %s\n", location, errObj.Message)
+		return ExitError
+	}
+
+	return ExitOK
+}
+
+func newArgs(args []string) *object.Array {
+	elements := make([]object.Object, 0, len(args))
+	for _, a := range args {
+		elements = append(elements, &object.String{Value: a})
+	}
+	return &object.Array{Elements: elements}
+}
@@ -0,0 +1,153 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunScenarioFiles(t *testing.T) {
+	files := []string{
+		"../parser/scenario1.cok",
+		"../parser/scenario2.cok",
+		"../parser/return-scenario1.cok",
+		"../lexer/scenario1.cok",
+	}
+
+	for _, file := range files {
+		var stdout, stderr bytes.Buffer
+
+		code := RunFile(file, nil, nil, &stdout, &stderr)
+		if code != ExitOK {
+			t.Errorf("%s: exit code wrong. expected=%d, got=%d\n%s", file, ExitOK, code, stderr.String())
+		}
+	}
+}
+
+func TestRunReportsSyntaxErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run("bad.cok", "let x = 5;\nlet y 10;\n", nil, &stdout, &stderr)
+	if code != ExitError {
+		t.Fatalf("exit code wrong. expected=%d, got=%d", ExitError, code)
+	}
+
+	expected := `error[E001]: expected next token to be =, got INT instead
+ --> bad.cok:2:7
+  |
+2 | let y 10;
+  |       ^^ unexpected token
+`
+	if !strings.HasPrefix(stderr.String(), expected) {
+		t.Errorf("wrong stderr.\nexpected prefix:\n%s\ngot:\n%s", expected, stderr.String())
+	}
+}
+
+func TestRunReportsRuntimeErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run("main.cok", "let x = 5;\nx + true;\n", nil, &stdout, &stderr)
+	if code != ExitError {
+		t.Fatalf("exit code wrong. expected=%d, got=%d", ExitError, code)
+	}
+
+	expected := "main.cok:2:1: runtime error: type mismatch: INTEGER + BOOLEAN\n"
+	if stderr.String() != expected {
+		t.Errorf("wrong stderr. expected=%q, got=%q", expected, stderr.String())
+	}
+}
+
+func TestRunReportsRuntimeErrorPositions(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{"let s = \"a\";\nputs(s + 1);\n", "<stdin>:2:6: runtime error: type mismatch: STRING + INTEGER\n"},
+		{"let x = 1;\n\nputs(y);\n", "<stdin>:3:6: runtime error: identifier not found: y\n"},
+		{"let xs = [1];\nxs[3];\n", "<stdin>:2:1: runtime error: index out of range: 3 (array length 1)\n"},
+		{"let h = {[1]: 2};\n", "<stdin>:1:10: runtime error: unusable as hash key: ARRAY\n"},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+
+		if code := Run("<stdin>", tt.src, nil, &stdout, &stderr); code != ExitError {
+			t.Fatalf("%q: exit code wrong. expected=%d, got=%d", tt.src, ExitError, code)
+		}
+		if stderr.String() != tt.expected {
+			t.Errorf("%q: wrong stderr. expected=%q, got=%q", tt.src, tt.expected, stderr.String())
+		}
+	}
+}
+
+func TestRunWritesPutsToStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	src := "puts(\"hello\", len(args));\nputs(push(args, 3));\n"
+	code := Run("puts.cok", src, []string{"a", "b"}, &stdout, &stderr)
+	if code != ExitOK {
+		t.Fatalf("exit code wrong. expected=%d, got=%d\n%s", ExitOK, code, stderr.String())
+	}
+
+	expected := "hello\n2\n[a, b, 3]\n"
+	if stdout.String() != expected {
+		t.Errorf("wrong stdout. expected=%q, got=%q", expected, stdout.String())
+	}
+}
+
+func TestRunReportsBuiltinErrorsAtCallSite(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run("main.cok", "let xs = [1, 2];\nlet n =  len(xs, 1);\n", nil, &stdout, &stderr)
+	if code != ExitError {
+		t.Fatalf("exit code wrong. expected=%d, got=%d", ExitError, code)
+	}
+
+	expected := "main.cok:2:10: runtime error: wrong number of arguments to `len`: got=2, want=1\n"
+	if stderr.String() != expected {
+		t.Errorf("wrong stderr. expected=%q, got=%q", expected, stderr.String())
+	}
+}
+
+func TestRunBindsArgs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	src := `if (args[1] == "b") { 1 } else { 1 + true }`
+	code := Run("args.cok", src, []string{"a", "b"}, &stdout, &stderr)
+	if code != ExitOK {
+		t.Errorf("exit code wrong. expected=%d, got=%d\n%s", ExitOK, code, stderr.String())
+	}
+}
+
+func TestRunFileFromStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := RunFile(StdinName, nil, strings.NewReader("let x = ;"), &stdout, &stderr)
+	if code != ExitError {
+		t.Fatalf("exit code wrong. expected=%d, got=%d", ExitError, code)
+	}
+
+	if !strings.Contains(stderr.String(), "--> <stdin>:1:9") {
+		t.Errorf("stderr does not mention <stdin>:1:9. got=%q", stderr.String())
+	}
+}
+
+func TestRunFileMissing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	path := filepath.Join(t.TempDir(), "missing.cok")
+	code := RunFile(path, nil, nil, &stdout, &stderr)
+	if code != ExitUsage {
+		t.Fatalf("exit code wrong. expected=%d, got=%d", ExitUsage, code)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		t.Fatalf("file should not exist")
+	}
+
+	if !strings.HasPrefix(stderr.String(), "cok: ") {
+		t.Errorf("wrong stderr. got=%q", stderr.String())
+	}
+}
@@ -1,13 +1,23 @@
 package token
 
+import (
+	"fmt"
+	"sort"
+)
+
 // definisikan token type
 const (
 	ILEGAL = "ILEGAL"
 	EOF    = "EOF"
 
+	// COMMENT hanya dihasilkan jika lexer dibuat dengan lexer.WithComments
+	COMMENT = "COMMENT" // // komentar atau /* komentar */
+
 	// identifiers + literal
-	IDENT = "IDENT" // add, foobar, x,y ......
-	INT   = "INT"   // 1234567
+	IDENT  = "IDENT"  // add, foobar, x,y ......
+	INT    = "INT"    // 1234567, 0xFF, 0o17, 0b1010, 1_000
+	FLOAT  = "FLOAT"  // 3.14, 1e-9
+	STRING = "STRING" // "foobar"
 
 	// operator
 	ASSIGN   = "="
@@ -22,11 +32,14 @@ const (
 	// Delimiters
 	COMMA     = ","
 	SEMICOLON = ";"
+	COLON     = ":"
 
-	LPAREN = "("
-	RPAREN = ")"
-	LBRACE = "{"
-	RBRACE = "}"
+	LPAREN   = "("
+	RPAREN   = ")"
+	LBRACE   = "{"
+	RBRACE   = "}"
+	LBRACKET = "["
+	RBRACKET = "]"
 
 	// keyword
 	FUNCTION = "FUNCTION"
@@ -47,6 +60,38 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+	Pos     Position // posisi karakter pertama dari token
+	End     Position // posisi tepat setelah karakter terakhir dari token
+}
+
+// Position menunjukkan lokasi di dalam kode sumber.
+// Line dan Column dimulai dari 1, sedangkan Offset adalah indeks byte yang dimulai dari 0.
+// Column dihitung dalam rune, sehingga "é" atau "ä" dihitung sebagai satu kolom.
+// Position dengan Line 0 dianggap tidak valid, misalnya untuk token yang dibuat secara manual di dalam test.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+	Offset int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String mengembalikan posisi dalam format file:line:column,
+// atau line:column jika nama file tidak diketahui.
+func (p Position) String() string {
+	if !p.IsValid() {
+		if p.File != "" {
+			return p.File
+		}
+		return "-"
+	}
+	if p.File != "" {
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
 }
 
 var keywords = map[string]TokenType{
@@ -68,3 +113,14 @@ func LookupIdent(ident string) TokenType {
 	}
 	return IDENT
 }
+
+// Keywords mengembalikan semua kata kunci bahasa COK, diurutkan secara alfabetis.
+// Dipakai misalnya oleh REPL untuk melengkapi (tab completion) kata kunci.
+func Keywords() []string {
+	names := make([]string, 0, len(keywords))
+	for name := range keywords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}